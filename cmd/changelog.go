@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zostay/zedpm/pkg/changes"
+	"github.com/zostay/zedpm/pkg/group"
+	"github.com/zostay/zedpm/plugin/master"
+)
+
+// changelogAddTask is the task that implements the changelog add command.
+const changelogAddTask = "/generate/entry/changelog"
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Work with the project changelog.",
+}
+
+func init() {
+	changelogCmd.PersistentFlags().StringP("target", "t", "default", "the target configuration to use")
+	changelogCmd.PersistentFlags().StringToStringP("define", "d", nil, "define a variable in a=b format")
+}
+
+// findTask returns the task with the given path or nil if no plugin implements
+// it. The task path may be given with or without the leading slash.
+func findTask(goals []*group.Goal, taskPath string) *group.Task {
+	taskPath = strings.TrimPrefix(taskPath, "/")
+	for _, goal := range goals {
+		for _, phase := range goal.Phases {
+			for _, task := range phase.InterleavedTasks {
+				if task.Path() == taskPath {
+					return task
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// configureChangelog attaches the changelog command and its subcommands to the
+// root command. Nothing is attached if no plugin implements the tasks they
+// depend upon.
+func configureChangelog(
+	ctx context.Context,
+	goals []*group.Goal,
+	e *master.InterfaceExecutor,
+) {
+	addCmd := newChangelogAddCommand(ctx, goals, e)
+	if addCmd == nil {
+		return
+	}
+
+	changelogCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(changelogCmd)
+}
+
+// newChangelogAddCommand returns the changelog add command or nil if no plugin
+// implements the task it runs.
+func newChangelogAddCommand(
+	ctx context.Context,
+	goals []*group.Goal,
+	e *master.InterfaceExecutor,
+) *cobra.Command {
+	task := findTask(goals, changelogAddTask)
+	if task == nil {
+		return nil
+	}
+
+	run := RunGoal(ctx, e, []*group.Phase{
+		{InterleavedTasks: []*group.Task{task}},
+	})
+
+	addCmd := &cobra.Command{
+		Use:   "add [ --category <category> ] [ --pr <number> ] <text>",
+		Short: "Add an entry to the changelog.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			category, _ := cmd.Flags().GetString("category")
+			pr, _ := cmd.Flags().GetString("pr")
+			if category != "" {
+				if _, err := changes.ParseCategory(category); err != nil {
+					return err
+				}
+			}
+
+			e.Define(map[string]string{
+				changes.PropertyChangelogEntryText:     strings.Join(args, " "),
+				changes.PropertyChangelogEntryCategory: category,
+				changes.PropertyChangelogEntryPR:       pr,
+			})
+
+			return run(cmd, args)
+		},
+	}
+
+	addCmd.Flags().StringP("category", "c", "", "the category of change: "+strings.ToLower(strings.Join(changes.Categories, ", ")))
+	addCmd.Flags().StringP("pr", "p", "", "the number of the pull request making the change")
+
+	return addCmd
+}
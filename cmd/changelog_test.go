@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/plugin"
+	"github.com/zostay/zedpm/zedpm-plugin-changelog/changelogImpl"
+	"github.com/zostay/zedpm/zedpm-plugin-goals/goalsImpl"
+)
+
+// newChangelogCommand returns a copy of the changelog command with the add
+// command attached, run using the changelog plugin served from the current
+// process. The test changes into a new directory holding the given changelog.
+func newChangelogCommand(t *testing.T, changelog string) *cobra.Command {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Changes.md"), []byte(changelog), 0o644))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	e, goalGroups := newTestExecutor(t, map[string]plugin.Interface{
+		"goals":     &goalsImpl.Plugin{},
+		"changelog": &changelogImpl.Plugin{},
+	})
+
+	addCmd := newChangelogAddCommand(context.Background(), goalGroups, e)
+	require.NotNil(t, addCmd)
+
+	cmd := &cobra.Command{Use: "changelog"}
+	cmd.PersistentFlags().AddFlagSet(changelogCmd.PersistentFlags())
+	cmd.AddCommand(addCmd)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	return cmd
+}
+
+func TestChangelogAdd(t *testing.T) {
+	cmd := newChangelogCommand(t, "v0.1.0  2023-01-01\n\n * Initial.\n")
+
+	cmd.SetArgs([]string{"add", "--category", "fixed", "--pr", "#12",
+		"The changelog add command wraps long entries so that the changelog stays", "readable."})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile("Changes.md")
+	require.NoError(t, err)
+	assert.Equal(t, `WIP  TBD
+
+ * Fix: The changelog add command wraps long entries so that the changelog
+   stays readable. (#12)
+
+v0.1.0  2023-01-01
+
+ * Initial.
+`, string(data))
+}
+
+func TestChangelogAddErrors(t *testing.T) {
+	const changelog = "WIP  TBD\n\n * Existing.\n"
+	cmd := newChangelogCommand(t, changelog)
+
+	cmd.SetArgs([]string{"add", "--category", "bogus", "An entry."})
+	assert.Error(t, cmd.Execute())
+
+	cmd.SetArgs([]string{"add"})
+	assert.Error(t, cmd.Execute())
+
+	data, err := os.ReadFile("Changes.md")
+	require.NoError(t, err)
+	assert.Equal(t, changelog, string(data))
+}
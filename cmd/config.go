@@ -103,10 +103,12 @@ func configureGoalCommand(
 	e *master.InterfaceExecutor,
 	runner CmdBuilder,
 ) *cobra.Command {
-	return &cobra.Command{
+	goalCmd := &cobra.Command{
 		Use:     goal.Name,
 		Short:   goal.Short(),
 		Aliases: goal.Aliases(),
 		RunE:    runner(ctx, e, goal.ExecutionPhases()),
 	}
+	addPropertyFlags(goalCmd, goal.Name)
+	return goalCmd
 }
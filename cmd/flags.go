@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/zostay/zedpm/pkg/goals"
+)
+
+// propertyFlag describes a command-line flag that is a shortcut for setting a
+// property with --define.
+type propertyFlag struct {
+	name     string
+	property string
+	usage    string
+}
+
+// goalPropertyFlags lists the property flags to add to the command for each
+// goal. These are inherited by the goal's phase and task commands.
+var goalPropertyFlags = map[string][]propertyFlag{
+	goals.NameInfo: {
+		{"get", goals.PropertyInfoQuery, "output only the values of the comma-separated keys, which may use * wildcards"},
+	},
+	goals.NameRelease: {
+		{"bump", goals.PropertyReleaseBump, "compute the next version by bumping major, minor, patch, or prerelease"},
+	},
+}
+
+// addPropertyFlags adds the property flags for the named goal to the command.
+func addPropertyFlags(cmd *cobra.Command, goalName string) {
+	for _, f := range goalPropertyFlags[goalName] {
+		cmd.PersistentFlags().String(f.name, "", f.usage)
+	}
+}
+
+// propertyFlagValues returns the properties set by any property flags that
+// were given on the command-line.
+func propertyFlagValues(cmd *cobra.Command) map[string]string {
+	values := map[string]string{}
+	for _, fs := range goalPropertyFlags {
+		for _, f := range fs {
+			flag := cmd.Flags().Lookup(f.name)
+			if flag == nil || !flag.Changed {
+				continue
+			}
+			values[f.property] = flag.Value.String()
+		}
+	}
+	return values
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zostay/zedpm/config"
+	"github.com/zostay/zedpm/pkg/conventional"
+	"github.com/zostay/zedpm/pkg/git"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage the git hooks that run zedpm.",
+}
+
+// configureHooks attaches the hooks command and its subcommands to the root
+// command. The hooks installed are those configured in the hooks block of the
+// configuration.
+func configureHooks(cfg *config.Config) {
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the git hooks configured in the hooks block.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			commands := cfg.Hooks.Commands()
+			if len(commands) == 0 {
+				return errors.New("no hooks are configured in the hooks block")
+			}
+
+			dir, err := git.HooksDir(".")
+			if err != nil {
+				return err
+			}
+
+			err = git.InstallHooks(dir, commands)
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(commands))
+			for name := range commands {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				fmt.Fprintf(cmd.OutOrStdout(), "Installed %s hook.\n", name)
+			}
+
+			return nil
+		},
+	}
+
+	uninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the git hooks installed by zedpm and restore the hooks they replaced.",
+		Args:  cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			dir, err := git.HooksDir(".")
+			if err != nil {
+				return err
+			}
+
+			return git.UninstallHooks(dir)
+		},
+	}
+
+	lintMessageCmd := &cobra.Command{
+		Use:   "lint-message <file>",
+		Short: "Check that the commit message in the file is a conventional commit.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			msg, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			return conventional.CheckMessage(string(msg))
+		},
+	}
+
+	hooksCmd.AddCommand(installCmd, uninstallCmd, lintMessageCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/zostay/zedpm/plugin"
+)
+
+// isInteractive returns true when standard input is a terminal, which means
+// that we may prompt the user for input. Other character devices, such as
+// /dev/null, are not terminals. This is a variable so that tests may replace
+// it.
+var isInteractive = func() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// promptRequirement asks the user for the value of a single requirement. It
+// repeats the question until an acceptable value is given.
+func promptRequirement(
+	in *bufio.Reader,
+	out io.Writer,
+	req *plugin.Requirement,
+) (string, error) {
+	label := req.Key
+	if req.Description != "" {
+		label = fmt.Sprintf("%s (%s)", req.Description, req.Key)
+	}
+
+	for {
+		if req.Default != "" {
+			fmt.Fprintf(out, "%s [%s]: ", label, req.Default)
+		} else {
+			fmt.Fprintf(out, "%s: ", label)
+		}
+
+		line, err := in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+
+		value := strings.TrimSpace(line)
+		if value == "" {
+			value = req.Default
+		}
+
+		if err := req.Check(value); err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			continue
+		}
+
+		return value, nil
+	}
+}
+
+// resolveRequirements determines values for the given missing requirements.
+// When interactive, the user is prompted for each value. Otherwise, any
+// requirement with a default is given its default value and an error listing
+// every remaining missing key is returned if there are any.
+func resolveRequirements(
+	missing []plugin.Requirement,
+	interactive bool,
+	in io.Reader,
+	out io.Writer,
+) (map[string]string, error) {
+	values := make(map[string]string, len(missing))
+	if interactive {
+		rin := bufio.NewReader(in)
+		for i := range missing {
+			req := &missing[i]
+			value, err := promptRequirement(rin, out, req)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read a value for %q: %w", req.Key, err)
+			}
+			values[req.Key] = value
+		}
+		return values, nil
+	}
+
+	stillMissing := make([]string, 0, len(missing))
+	for i := range missing {
+		req := &missing[i]
+		if req.Default == "" {
+			stillMissing = append(stillMissing, req.Key)
+			continue
+		}
+
+		if err := req.Check(req.Default); err != nil {
+			return nil, err
+		}
+
+		values[req.Key] = req.Default
+	}
+
+	if len(stillMissing) > 0 {
+		return nil, fmt.Errorf("missing required properties (set with -d <key>=<value>): %s", strings.Join(stillMissing, ", "))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestPromptRequirement(t *testing.T) {
+	t.Parallel()
+
+	req := &plugin.Requirement{
+		Key:         "release.version",
+		Description: "Release version",
+		Pattern:     `^\d+\.\d+\.\d+$`,
+	}
+
+	var out strings.Builder
+	in := bufio.NewReader(strings.NewReader("\nv1.2.3\n 1.2.3 \n"))
+	value, err := promptRequirement(in, &out, req)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", value)
+	assert.Equal(t, `Release version (release.version): a value for "release.version" is required
+Release version (release.version): value "v1.2.3" for "release.version" does not match ^\d+\.\d+\.\d+$
+Release version (release.version): `, out.String())
+
+	// running out of input without an answer is an error
+	req.Default = "2.0.0"
+	out.Reset()
+	value, err = promptRequirement(bufio.NewReader(strings.NewReader("")), &out, req)
+	require.Error(t, err)
+	assert.Empty(t, value)
+
+	// an empty answer accepts the default
+	out.Reset()
+	value, err = promptRequirement(bufio.NewReader(strings.NewReader("\n")), &out, req)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", value)
+	assert.Equal(t, "Release version (release.version) [2.0.0]: ", out.String())
+}
+
+func TestResolveRequirements(t *testing.T) {
+	t.Parallel()
+
+	missing := []plugin.Requirement{
+		{Key: "git.tag", Default: "v1.2.3"},
+		{Key: "release.version", Pattern: `^\d`},
+	}
+
+	var out strings.Builder
+	values, err := resolveRequirements(missing, true, strings.NewReader("\n1.2.3\n"), &out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"git.tag": "v1.2.3", "release.version": "1.2.3"}, values)
+	assert.Equal(t, "git.tag [v1.2.3]: release.version: ", out.String())
+
+	_, err = resolveRequirements(missing, true, strings.NewReader("\n"), &out)
+	assert.ErrorContains(t, err, `failed to read a value for "release.version"`)
+
+	// non-interactive runs use the defaults and list everything else
+	_, err = resolveRequirements(append(missing, plugin.Requirement{Key: "a.b"}), false, nil, nil)
+	assert.EqualError(t, err,
+		"missing required properties (set with -d <key>=<value>): release.version, a.b")
+
+	values, err = resolveRequirements(missing[:1], false, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"git.tag": "v1.2.3"}, values)
+
+	_, err = resolveRequirements([]plugin.Requirement{{Key: "x", Default: "y", Pattern: `^\d`}}, false, nil, nil)
+	assert.EqualError(t, err, `value "y" for "x" does not match ^\d`)
+}
@@ -113,6 +113,8 @@ func Execute() int {
 
 	configureGoalsPhasesAndTasks(ctx, goals, e, runCmd, RunGoal)
 	configureGoals(ctx, goals, e, depsCmd, RunDepsForGoal)
+	configureChangelog(ctx, goals, e)
+	configureHooks(cfg)
 
 	err = rootCmd.Execute()
 	cobra.CheckErr(err)
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/pkg/group"
 	"github.com/zostay/zedpm/plugin/master"
 )
@@ -20,23 +23,60 @@ var runCmd = &cobra.Command{
 func init() {
 	runCmd.PersistentFlags().StringP("target", "t", "default", "the target configuration to use")
 	runCmd.PersistentFlags().StringToStringP("define", "d", nil, "define a variable in a=b format")
+	runCmd.PersistentFlags().StringP("module", "m", "", "the directory of the module to run the goal for or \"all\" to run it for every module")
 	// TODO Figure out a contract that we can use when defining plugins to enforce dry-run that we can be relatively sure will always work...
 	// runCmd.PersistentFlags().Bool("dry-run", false, "describe what would happen if the command run without doing it")
 }
 
+// allModules is the special value of the --module option that selects every
+// module in the repository.
+const allModules = "all"
+
+// selectModules returns the module directories named by the --module option.
+// If no module is named, a single empty string is returned, meaning the goal
+// runs without setting module.dir.
+func selectModules(module string) ([]string, error) {
+	if module == "" {
+		return []string{""}, nil
+	}
+
+	modules, err := goals.FindModules(".")
+	if err != nil {
+		return nil, err
+	}
+
+	if module == allModules {
+		if len(modules) == 0 {
+			return nil, fmt.Errorf("no go.mod files found")
+		}
+		return modules, nil
+	}
+
+	module = path.Clean(module)
+	for _, m := range modules {
+		if m == module {
+			return []string{m}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no module found in directory %q", module)
+}
+
 // RunGoal returns a command runner for cobra that will execute a particular
-// goal or subtask.
+// goal or subtask. When the --module option selects more than one module, the
+// goal is executed once per module, starting over with fresh properties each
+// time.
 func RunGoal(
 	ctx context.Context,
 	e *master.InterfaceExecutor,
 	phases []*group.Phase,
 ) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		target, _ := cmd.Flags().GetString("target")
-		e.SetTargetName(target)
-
-		values, _ := cmd.Flags().GetStringToString("define")
-		e.Define(values)
+		module, _ := cmd.Flags().GetString("module")
+		modules, err := selectModules(module)
+		if err != nil {
+			return err
+		}
 
 		caser := cases.Title(language.AmericanEnglish)
 		phaseNames := make([]string, len(phases))
@@ -57,15 +97,63 @@ func RunGoal(
 			}
 		}
 
-		phasePlan := e.PreparePhasePlan(phases)
-		for phasePlan.NextPhase() {
-			err := phasePlan.ExecutePhase(ctx)
+		for i, module := range modules {
+			if i > 0 {
+				e.Reset()
+			}
+
+			err := runModule(ctx, cmd, e, phases, module)
 			if err != nil {
-				logger.Error("failed to execute phase", "phase", phasePlan.CurrentPhase().Name, "error", err)
-				exitStatus = 1
+				return err
 			}
 		}
 
 		return nil
 	}
 }
+
+// runModule executes the phases of the goal for a single module. If module is
+// empty, module.dir is left as configured.
+func runModule(
+	ctx context.Context,
+	cmd *cobra.Command,
+	e *master.InterfaceExecutor,
+	phases []*group.Phase,
+	module string,
+) error {
+	target, _ := cmd.Flags().GetString("target")
+	e.SetTargetName(target)
+
+	e.Define(propertyFlagValues(cmd))
+
+	values, _ := cmd.Flags().GetStringToString("define")
+	e.Define(values)
+
+	if module != "" {
+		e.Define(map[string]string{goals.PropertyModuleDir: module})
+	}
+
+	missing, err := e.MissingRequirements(ctx, phases)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		values, err := resolveRequirements(missing, isInteractive(), os.Stdin, os.Stderr)
+		if err != nil {
+			return err
+		}
+		e.Define(values)
+	}
+
+	phasePlan := e.PreparePhasePlan(phases)
+	for phasePlan.NextPhase() {
+		err := phasePlan.ExecutePhase(ctx)
+		if err != nil {
+			logger.Error("failed to execute phase", "module", module, "phase", phasePlan.CurrentPhase().Name, "error", err)
+			exitStatus = 1
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/config"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/group"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+	"github.com/zostay/zedpm/plugin/master"
+	"github.com/zostay/zedpm/plugin/metal"
+	"github.com/zostay/zedpm/zedpm-plugin-git/gitImpl"
+	"github.com/zostay/zedpm/zedpm-plugin-goals/goalsImpl"
+)
+
+// runGit runs the git command in the given directory.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// initReleaseRepo creates a repository with a release tagged v1.2.3 that has
+// been pushed to a bare origin repository and changes into it for the rest of
+// the test. It returns the directory of the origin.
+func initReleaseRepo(t *testing.T) string {
+	t.Helper()
+
+	origin := t.TempDir()
+	runGit(t, origin, "init", "-q", "--bare", "-b", "master")
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "master")
+	runGit(t, dir, "config", "user.name", "Test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "commit.gpgSign", "false")
+	runGit(t, dir, "config", "tag.gpgSign", "false")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+	runGit(t, dir, "tag", "v1.2.3")
+	runGit(t, dir, "remote", "add", "origin", origin)
+	runGit(t, dir, "push", "-q", "origin", "master", "v1.2.3")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	return origin
+}
+
+// newTestExecutor serves the given plugins from the current process and
+// returns an executor for them along with the goals they implement. The
+// package globals normally set up by Execute are set up for the test, too.
+func newTestExecutor(
+	t *testing.T,
+	ifaces map[string]plugin.Interface,
+) (*master.InterfaceExecutor, []*group.Goal) {
+	t.Helper()
+
+	lg := hclog.NewNullLogger()
+	clients := metal.Clients{}
+	for name, iface := range ifaces {
+		client, err := metal.LoadLocalPlugin(iface, lg, io.Discard, io.Discard)
+		require.NoError(t, err)
+		clients[name] = client
+	}
+	t.Cleanup(func() { metal.KillPlugins(clients) })
+
+	dispensed, err := metal.DispenseAll(clients)
+	require.NoError(t, err)
+
+	cfg := &config.Config{Properties: storage.New().RO()}
+	e := master.NewExecutor(lg, master.NewInterface(lg, cfg, dispensed))
+
+	ctx := context.Background()
+	goalGroups, err := e.PotentialGoalsPhasesAndTasks(ctx)
+	require.NoError(t, err)
+
+	logger = hclog.NewInterceptLogger(&hclog.LoggerOptions{Level: hclog.Off})
+	exitStatus = 0
+	interactive := isInteractive
+	isInteractive = func() bool { return false }
+	t.Cleanup(func() { isInteractive = interactive })
+
+	return e, goalGroups
+}
+
+// newGoalCommand serves the given plugins from the current process and returns
+// the command for running the named goal, attached to a copy of the run
+// command.
+func newGoalCommand(
+	t *testing.T,
+	ifaces map[string]plugin.Interface,
+	goalName string,
+) *cobra.Command {
+	t.Helper()
+
+	e, goalGroups := newTestExecutor(t, ifaces)
+
+	run := &cobra.Command{Use: "run"}
+	run.PersistentFlags().AddFlagSet(runCmd.PersistentFlags())
+	for _, goal := range goalGroups {
+		if goal.Name == goalName {
+			run.AddCommand(configureGoalCommand(context.Background(), goal, e, RunGoal))
+		}
+	}
+
+	return run
+}
+
+func TestRunReleaseWithBump(t *testing.T) {
+	origin := initReleaseRepo(t)
+
+	run := newGoalCommand(t, map[string]plugin.Interface{
+		"goals": &goalsImpl.Plugin{},
+		"git":   &gitImpl.Plugin{},
+	}, goals.NameRelease)
+
+	run.SetArgs([]string{"release", "--bump", "minor"})
+	require.NoError(t, run.Execute())
+	assert.Equal(t, 0, exitStatus)
+
+	assert.Equal(t, runGit(t, origin, "rev-parse", "master"), runGit(t, origin, "rev-list", "-n", "1", "v1.3.0"))
+	assert.Equal(t, "releng: v1.3.0", runGit(t, origin, "log", "-1", "--format=%s", "release-v1.3.0"))
+}
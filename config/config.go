@@ -45,6 +45,40 @@ type Config struct {
 
 	// Plugins is the configuration to apply to each plugin.
 	Plugins []PluginConfig
+
+	// Hooks is the configuration of the git hooks installed by zedpm.
+	Hooks HooksConfig
+}
+
+// HooksConfig names the shell commands run by the git hooks that zedpm
+// installs. A hook is only installed if its command is set. The arguments git
+// passes to the hook are available to the command as $1, $2, etc.
+type HooksConfig struct {
+	// PreCommit is the command run before each commit, e.g., "zedpm run lint".
+	PreCommit string
+
+	// CommitMsg is the command run to check each commit message, e.g.,
+	// `zedpm hooks lint-message "$1"`.
+	CommitMsg string
+
+	// PrePush is the command run before each push, e.g., "zedpm run test".
+	PrePush string
+}
+
+// Commands returns the configured hook commands, keyed by the name of the git
+// hook that runs each.
+func (h HooksConfig) Commands() map[string]string {
+	cmds := map[string]string{}
+	for name, cmd := range map[string]string{
+		"pre-commit": h.PreCommit,
+		"commit-msg": h.CommitMsg,
+		"pre-push":   h.PrePush,
+	} {
+		if cmd != "" {
+			cmds[name] = cmd
+		}
+	}
+	return cmds
 }
 
 // PluginConfig holds the configuration to use for a particular plugin.
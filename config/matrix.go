@@ -0,0 +1,219 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+// MatrixPropertyPrefix is the prefix given to each property injected into a
+// target that was generated from a matrix. For example, a matrix dimension
+// named goos will be made available to the target as the property
+// "matrix.goos".
+const MatrixPropertyPrefix = "matrix."
+
+// RawMatrixConfig is the configuration specification for HCL for a matrix
+// block. Every attribute in the block is a dimension of the matrix and must be
+// set to a list of values.
+type RawMatrixConfig struct {
+	Dimensions hcl.Attributes `hcl:",remain"`
+}
+
+// MatrixDimension is a single named axis of a matrix.
+type MatrixDimension struct {
+	// Name is the name of the dimension.
+	Name string
+
+	// Values are the values of the dimension, in the order they were
+	// configured.
+	Values []string
+}
+
+// MatrixConfig describes a set of targets to generate by taking the cartesian
+// product of each of the dimension's values.
+type MatrixConfig struct {
+	// Dimensions are the axes of the matrix, in the order they were
+	// configured.
+	Dimensions []MatrixDimension
+}
+
+// MatrixCell is a single combination of values taken from a matrix.
+type MatrixCell []MatrixValue
+
+// MatrixValue is a value of a single dimension in a MatrixCell.
+type MatrixValue struct {
+	Name  string
+	Value string
+}
+
+// Name returns the name of the cell, which is each of the values joined by a
+// hyphen.
+func (c MatrixCell) Name() string {
+	names := make([]string, len(c))
+	for i, v := range c {
+		names[i] = v.Value
+	}
+	return strings.Join(names, "-")
+}
+
+// Properties returns the properties that are injected into the target created
+// for this cell.
+func (c MatrixCell) Properties() storage.KV {
+	props := storage.New()
+	for _, v := range c {
+		props.Set(MatrixPropertyPrefix+v.Name, v.Value)
+	}
+	return props.RO()
+}
+
+// Cells returns every combination of values in the matrix. The last dimension
+// varies fastest, so for dimensions goos = ["linux", "darwin"] and goarch =
+// ["amd64", "arm64"], the cells returned are linux-amd64, linux-arm64,
+// darwin-amd64, and darwin-arm64.
+func (m *MatrixConfig) Cells() []MatrixCell {
+	if m == nil || len(m.Dimensions) == 0 {
+		return nil
+	}
+
+	cells := []MatrixCell{{}}
+	for _, dim := range m.Dimensions {
+		next := make([]MatrixCell, 0, len(cells)*len(dim.Values))
+		for _, cell := range cells {
+			for _, val := range dim.Values {
+				newCell := make(MatrixCell, len(cell), len(cell)+1)
+				copy(newCell, cell)
+				newCell = append(newCell, MatrixValue{dim.Name, val})
+				next = append(next, newCell)
+			}
+		}
+		cells = next
+	}
+
+	return cells
+}
+
+// Targets expands the matrix into a list of TargetConfig objects. The name of
+// each target is the name of the cell, prefixed by the name of the given
+// target if it has a name. The given target also provides the enabled and
+// disabled plugin lists and any properties, which override the matrix values.
+func (m *MatrixConfig) Targets(tmpl *TargetConfig) []TargetConfig {
+	cells := m.Cells()
+	targets := make([]TargetConfig, len(cells))
+	for i, cell := range cells {
+		name := cell.Name()
+		if tmpl.Name != "" {
+			name = tmpl.Name + "-" + name
+		}
+
+		targets[i] = TargetConfig{
+			Name:            name,
+			EnabledPlugins:  tmpl.EnabledPlugins,
+			DisabledPlugins: tmpl.DisabledPlugins,
+			Properties:      storage.Layers(tmpl.Properties, cell.Properties()),
+		}
+	}
+	return targets
+}
+
+// decodeRawMatrix converts a RawMatrixConfig into a MatrixConfig. Dimensions
+// are kept in the order they appear in the configuration file so that the
+// generated target names are predictable. It fails if two cells would generate
+// targets with the same name, e.g., because a dimension repeats a value or
+// values contain hyphens, since one target would silently replace the other.
+func decodeRawMatrix(prefix string, in *RawMatrixConfig) (*MatrixConfig, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	attrs := make([]*hcl.Attribute, 0, len(in.Dimensions))
+	for _, attr := range in.Dimensions {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].NameRange.Start.Byte < attrs[j].NameRange.Start.Byte
+	})
+
+	dims := make([]MatrixDimension, len(attrs))
+	for i, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		ty := val.Type()
+		if !ty.IsTupleType() && !ty.IsListType() && !ty.IsSetType() {
+			return nil, fmt.Errorf("%s must be set to a list of values", p(prefix, "matrix")+attr.Name)
+		}
+
+		strs, err := convert.Convert(val, cty.List(cty.String))
+		if err != nil {
+			return nil, fmt.Errorf("%s must contain only strings, numbers, or booleans: %w", p(prefix, "matrix")+attr.Name, err)
+		}
+
+		var values []string
+		if err := gocty.FromCtyValue(strs, &values); err != nil {
+			return nil, fmt.Errorf("%s %w", p(prefix, "matrix")+attr.Name, err)
+		}
+
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%s must contain at least one value", p(prefix, "matrix")+attr.Name)
+		}
+
+		dims[i] = MatrixDimension{
+			Name:   attr.Name,
+			Values: values,
+		}
+	}
+
+	m := &MatrixConfig{dims}
+	names := map[string]struct{}{}
+	for _, cell := range m.Cells() {
+		name := cell.Name()
+		if _, dup := names[name]; dup {
+			return nil, fmt.Errorf("%smatrix generates more than one target named %q", prefix, name)
+		}
+		names[name] = struct{}{}
+	}
+
+	return m, nil
+}
+
+// expandMatrixTargets returns the targets generated from the matrix merged with
+// the explicitly configured targets. When an explicit target has the same name
+// as a generated target, the properties of the explicit target are layered over
+// the properties of the generated target.
+func expandMatrixTargets(
+	matrix *MatrixConfig,
+	targets []TargetConfig,
+) []TargetConfig {
+	if matrix == nil {
+		return targets
+	}
+
+	out := matrix.Targets(&TargetConfig{Properties: storage.New().RO()})
+	for _, target := range targets {
+		merged := false
+		for i := range out {
+			if out[i].Name == target.Name {
+				out[i].EnabledPlugins = target.EnabledPlugins
+				out[i].DisabledPlugins = target.DisabledPlugins
+				out[i].Properties = storage.Layers(target.Properties, out[i].Properties)
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			out = append(out, target)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const matrixConfig = `
+goal "build" {
+  matrix {
+    goos   = ["linux", "darwin"]
+    goarch = ["amd64", "arm64"]
+  }
+
+  target "darwin-arm64" {
+    properties = {
+      cgo = true
+    }
+  }
+
+  phase "package" {
+    target "dist" {
+      properties = {
+        format = "tar"
+      }
+
+      matrix {
+        compress = ["gz", "xz"]
+      }
+    }
+  }
+}
+`
+
+func TestMatrix(t *testing.T) {
+	cfg, err := Load("zedpm.conf", strings.NewReader(matrixConfig))
+	require.NoError(t, err)
+
+	goal := cfg.GetGoal("build")
+	require.NotNil(t, goal)
+
+	names := make([]string, len(goal.Targets))
+	for i := range goal.Targets {
+		names[i] = goal.Targets[i].Name
+	}
+	assert.Equal(t, []string{"linux-amd64", "linux-arm64", "darwin-amd64", "darwin-arm64"}, names)
+
+	target := goal.GetTarget("linux-arm64")
+	require.NotNil(t, target)
+	assert.Equal(t, "linux", target.Properties.GetString("matrix.goos"))
+	assert.Equal(t, "arm64", target.Properties.GetString("matrix.goarch"))
+
+	target = goal.GetTarget("darwin-arm64")
+	require.NotNil(t, target)
+	assert.Equal(t, "darwin", target.Properties.GetString("matrix.goos"))
+	assert.True(t, target.Properties.GetBool("cgo"))
+
+	require.Len(t, goal.Phases, 1)
+	phase := &goal.Phases[0]
+	assert.Nil(t, phase.GetTarget("dist"))
+
+	target = phase.GetTarget("dist-xz")
+	require.NotNil(t, target)
+	assert.Equal(t, "xz", target.Properties.GetString("matrix.compress"))
+	assert.Equal(t, "tar", target.Properties.GetString("format"))
+
+	kv, err := cfg.ToKV(nil, "/build/package", "darwin-arm64", "")
+	require.NoError(t, err)
+	assert.Equal(t, "arm64", kv.GetString("matrix.goarch"))
+}
+
+func TestMatrixErrors(t *testing.T) {
+	_, err := Load("zedpm.conf", strings.NewReader(`
+goal "build" {
+  matrix {
+    goos = "linux"
+  }
+}
+`))
+	assert.Error(t, err)
+
+	_, err = Load("zedpm.conf", strings.NewReader(`
+goal "build" {
+  matrix {
+    goos = []
+  }
+}
+`))
+	assert.Error(t, err)
+
+	_, err = Load("zedpm.conf", strings.NewReader(`
+goal "build" {
+  matrix {
+    goos = ["linux", "linux"]
+  }
+}
+`))
+	assert.EqualError(t, err, `build.matrix generates more than one target named "linux"`)
+
+	_, err = Load("zedpm.conf", strings.NewReader(`
+goal "build" {
+  target "dist" {
+    matrix {
+      a = ["x-y", "x"]
+      b = ["z", "y-z"]
+    }
+  }
+}
+`))
+	assert.EqualError(t, err, `build.dist.matrix generates more than one target named "x-y-z"`)
+}
@@ -16,6 +16,15 @@ type RawConfig struct {
 
 	Goals   []RawGoalConfig   `hcl:"goal,block"`
 	Plugins []RawPluginConfig `hcl:"plugin,block"`
+	Hooks   *RawHooksConfig   `hcl:"hooks,block"`
+}
+
+// RawHooksConfig is the configuration specification for HCL for git hooks
+// configuration. See HooksConfig for details on what the fields represent.
+type RawHooksConfig struct {
+	PreCommit string `hcl:"pre-commit,optional"`
+	CommitMsg string `hcl:"commit-msg,optional"`
+	PrePush   string `hcl:"pre-push,optional"`
 }
 
 // RawPluginConfig is the configuration specification for HCL for plugin
@@ -37,6 +46,7 @@ type RawGoalConfig struct {
 
 	Properties cty.Value `hcl:"properties,optional"`
 
+	Matrix  *RawMatrixConfig  `hcl:"matrix,block"`
 	Phases  []RawPhaseConfig  `hcl:"phase,block"`
 	Targets []RawTargetConfig `hcl:"target,block"`
 }
@@ -77,6 +87,8 @@ type RawTargetConfig struct {
 	DisabledPlugins []string `hcl:"disabled,optional"`
 
 	Properties cty.Value `hcl:"properties,optional"`
+
+	Matrix *RawMatrixConfig `hcl:"matrix,block"`
 }
 
 // p is a helper used by decodeRawProperties to create prefixes.
@@ -153,10 +165,16 @@ func decodeRawConfig(rc *RawConfig) (*Config, error) {
 		return nil, err
 	}
 
+	var hooks HooksConfig
+	if rc.Hooks != nil {
+		hooks = HooksConfig(*rc.Hooks)
+	}
+
 	return &Config{
 		Properties: props,
 		Goals:      goals,
 		Plugins:    plugins,
+		Hooks:      hooks,
 	}, nil
 }
 
@@ -207,7 +225,12 @@ func decodeRawGoal(prefix string, in *RawGoalConfig) (*GoalConfig, error) {
 		return nil, err
 	}
 
-	targets, err := decodeRawList[RawTargetConfig, TargetConfig](pn, in.Targets, decodeRawTarget)
+	matrix, err := decodeRawMatrix(pn, in.Matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := decodeRawTargets(pn, in.Targets)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +241,7 @@ func decodeRawGoal(prefix string, in *RawGoalConfig) (*GoalConfig, error) {
 			EnabledPlugins:  in.EnabledPlugins,
 			DisabledPlugins: in.DisabledPlugins,
 			Properties:      props,
-			Targets:         targets,
+			Targets:         expandMatrixTargets(matrix, targets),
 		},
 		Phases: phases,
 	}, nil
@@ -237,7 +260,7 @@ func decodeRawPhase(prefix string, in *RawPhaseConfig) (*PhaseConfig, error) {
 		return nil, err
 	}
 
-	targets, err := decodeRawList[RawTargetConfig, TargetConfig](pn, in.Targets, decodeRawTarget)
+	targets, err := decodeRawTargets(pn, in.Targets)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +285,7 @@ func decodeRawTask(prefix string, in *RawTaskConfig) (*TaskConfig, error) {
 		return nil, err
 	}
 
-	targets, err := decodeRawList[RawTargetConfig, TargetConfig](pn, in.Targets, decodeRawTarget)
+	targets, err := decodeRawTargets(pn, in.Targets)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +301,33 @@ func decodeRawTask(prefix string, in *RawTaskConfig) (*TaskConfig, error) {
 	}, nil
 }
 
+// decodeRawTargets converts a []RawTargetConfig into a []TargetConfig. Any
+// target with a matrix block is replaced by the targets generated from that
+// matrix.
+func decodeRawTargets(prefix string, rs []RawTargetConfig) ([]TargetConfig, error) {
+	out := make([]TargetConfig, 0, len(rs))
+	for i := range rs {
+		r := &rs[i]
+		c, err := decodeRawTarget(prefix, r)
+		if err != nil {
+			return nil, err
+		}
+
+		matrix, err := decodeRawMatrix(p(prefix, r.Name), r.Matrix)
+		if err != nil {
+			return nil, err
+		}
+
+		if matrix == nil {
+			out = append(out, *c)
+			continue
+		}
+
+		out = append(out, matrix.Targets(c)...)
+	}
+	return out, nil
+}
+
 // decodeRawTarget converts a RawTargetConfig into a TargetConfig.
 func decodeRawTarget(prefix string, in *RawTargetConfig) (*TargetConfig, error) {
 	pn := p(prefix, in.Name)
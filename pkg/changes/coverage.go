@@ -0,0 +1,129 @@
+package changes
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	skipTrailer     = regexp.MustCompile(`(?mi)^changelog:\s*skip\s*$`)  // the trailer used to opt out of coverage
+	mergePRSubject  = regexp.MustCompile(`^Merge pull request #(\d+)\b`) // merge commits made by GitHub
+	squashPRSubject = regexp.MustCompile(`\(#(\d+)\)\s*$`)               // squash merges made by GitHub
+)
+
+// CoverageCommit is a commit to account for when checking changelog coverage.
+type CoverageCommit struct {
+	// Message is the complete commit message.
+	Message string
+
+	// IsMerge is true for merge commits, which are only used to find pull
+	// request numbers and do not count as changes on their own.
+	IsMerge bool
+}
+
+// SkipsChangelog returns true if the commit opts out of changelog coverage with
+// a "Changelog: skip" trailer.
+func (c *CoverageCommit) SkipsChangelog() bool {
+	return skipTrailer.MatchString(c.Message)
+}
+
+// PullRequest returns the number of the pull request merged by this commit or
+// an empty string if the commit does not name one.
+func (c *CoverageCommit) PullRequest() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	if m := mergePRSubject.FindStringSubmatch(subject); m != nil {
+		return m[1]
+	}
+	if m := squashPRSubject.FindStringSubmatch(subject); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// CoverageError describes the ways in which the changelog fails to account for
+// the commits made since the last release.
+type CoverageError struct {
+	Problems []string
+}
+
+// Error returns the problems as a bulleted list.
+func (e *CoverageError) Error() string {
+	return fmt.Sprintf("Change log coverage check failed:\n * %s", strings.Join(e.Problems, "\n * "))
+}
+
+// CheckCoverage compares the given commits to the given unreleased changelog
+// entries. Commits with a "Changelog: skip" trailer are ignored. It fails if:
+//
+//   - there are commits, but no entries,
+//   - there are fewer entries than minRatio times the number of commits, or
+//   - requirePRs is true and an entry does not mention "#N" for each pull
+//     request N merged by the commits.
+//
+// It returns a *CoverageError describing every failure or nil.
+func CheckCoverage(
+	commits []CoverageCommit,
+	entries []Entry,
+	minRatio float64,
+	requirePRs bool,
+) error {
+	changes := 0
+	prs := map[string]struct{}{}
+	for i := range commits {
+		c := &commits[i]
+		if c.SkipsChangelog() {
+			continue
+		}
+
+		if pr := c.PullRequest(); pr != "" {
+			prs[pr] = struct{}{}
+		}
+
+		if !c.IsMerge {
+			changes++
+		}
+	}
+
+	problems := []string{}
+	switch want := int(math.Ceil(minRatio * float64(changes))); {
+	case changes > 0 && len(entries) == 0:
+		problems = append(problems,
+			fmt.Sprintf("no unreleased changes are recorded, but there are %d commits since the last release", changes))
+	case len(entries) < want:
+		problems = append(problems,
+			fmt.Sprintf("only %d unreleased changes are recorded for %d commits since the last release, expected at least %d",
+				len(entries), changes, want))
+	}
+
+	if requirePRs {
+		missing := []string{}
+		for pr := range prs {
+			ref := regexp.MustCompile(`#` + pr + `\b`)
+			found := false
+			for _, e := range entries {
+				if ref.MatchString(e.Text) {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				missing = append(missing, "#"+pr)
+			}
+		}
+
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			problems = append(problems,
+				fmt.Sprintf("no unreleased changes mention merged pull requests %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &CoverageError{problems}
+	}
+
+	return nil
+}
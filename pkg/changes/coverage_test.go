@@ -0,0 +1,37 @@
+package changes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCoverage(t *testing.T) {
+	commits := []CoverageCommit{
+		{Message: "Merge pull request #12 from someone/feature\n\nAdd a feature", IsMerge: true},
+		{Message: "Add a feature"},
+		{Message: "Fix a typo\n\nChangelog: skip\n"},
+		{Message: "Speed things up (#14)"},
+	}
+
+	err := CheckCoverage(commits, nil, 0.1, true)
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"no unreleased changes are recorded, but there are 2 commits since the last release",
+		"no unreleased changes mention merged pull requests #12, #14",
+	}, err.(*CoverageError).Problems)
+
+	entries := []Entry{
+		{CategoryAdded, "A feature (#12)"},
+	}
+	err = CheckCoverage(commits, entries, 1, true)
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"only 1 unreleased changes are recorded for 2 commits since the last release, expected at least 2",
+		"no unreleased changes mention merged pull requests #14",
+	}, err.(*CoverageError).Problems)
+
+	assert.NoError(t, CheckCoverage(commits, entries, 0.5, false))
+	assert.NoError(t, CheckCoverage(nil, nil, 0.1, true))
+}
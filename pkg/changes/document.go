@@ -0,0 +1,645 @@
+package changes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// LineKind identifies the role a line plays in a changelog Document.
+type LineKind int
+
+const (
+	// LineHeading is a section heading, either a WIP heading or a version
+	// heading.
+	LineHeading LineKind = 0 + iota
+
+	// LineBullet is the first line of a bullet.
+	LineBullet
+
+	// LineContinuation continues the text of the bullet on the line before.
+	LineContinuation
+
+	// LineBlank is an empty line.
+	LineBlank
+
+	// LineOther is any line that is not part of the changelog format.
+	LineOther
+
+	// LineCategory is a heading naming the category of the bullets that
+	// follow it within a section.
+	LineCategory
+)
+
+// syntax describes how the lines of a particular changelog format are read and
+// written.
+type syntax struct {
+	// heading parses a section heading, returning the version and date it
+	// names. The version is empty for a section of unreleased changes.
+	heading func(line string) (version, date string, ok bool)
+
+	// formatHeading returns the heading line for the section.
+	formatHeading func(s *Section) string
+
+	bullet       *regexp.Regexp // bullet start lines
+	continuation *regexp.Regexp // bullet continuation lines
+
+	// formatBullet formats the text of a new or changed bullet.
+	formatBullet func(text string) string
+
+	category       *regexp.Regexp           // category headings, if the format has them
+	formatCategory func(name string) string // formats a new or changed category heading
+
+	epilogue *regexp.Regexp // lines that begin the epilogue, if the format has one
+}
+
+// zedpmSyntax is the syntax of the zedpm changelog format.
+var zedpmSyntax = &syntax{
+	heading: func(line string) (string, string, bool) {
+		if line == "WIP" || line == WIPHeading {
+			return "", "", true
+		}
+
+		if m := versionHeading.FindStringSubmatch(line); m != nil {
+			return m[1], m[2], true
+		}
+
+		return "", "", false
+	},
+	formatHeading: func(s *Section) string {
+		if s.IsWIP() {
+			return WIPHeading
+		}
+		return fmt.Sprintf("v%s  %s", s.Version, s.Date)
+	},
+	bullet:       logLineStart,
+	continuation: logLineContinuation,
+	formatBullet: FormatBullet,
+}
+
+// syntaxOf returns the syntax of the given document, which is zedpm's syntax
+// unless the document was parsed from some other format.
+func syntaxOf(d *Document) *syntax {
+	if d == nil || d.syntax == nil {
+		return zedpmSyntax
+	}
+	return d.syntax
+}
+
+// Line is a single line of a changelog Document.
+type Line struct {
+	Number int      // the 1-based line number
+	Text   string   // the text of the line, without the newline
+	Kind   LineKind // the role the line plays
+}
+
+// Block is a part of the body of a changelog section. It is either a *Bullet, a
+// *Category heading, or a *Raw line.
+type Block interface {
+	// Lines returns the lines of text making up the block.
+	Lines() []string
+}
+
+// Bullet is a single change bullet, which is made up of a line beginning with
+// " * " (or "- " in Keep a Changelog) and any continuation lines that follow.
+type Bullet struct {
+	// Text is the text of the bullet with the continuation lines joined by a
+	// single space. Changing the text causes the bullet to be reformatted
+	// when the Document is written.
+	Text string
+
+	// Line is the line number the bullet started on when parsed or 0 if the
+	// bullet has been added since.
+	Line int
+
+	raw    []string
+	parsed string
+	syntax *syntax
+}
+
+// Lines returns the lines of the bullet as parsed. If the bullet is new or the
+// text has changed, the text is formatted as a bullet of the changelog format,
+// which is done with FormatBullet for zedpm changelogs.
+func (b *Bullet) Lines() []string {
+	if b.raw != nil && b.Text == b.parsed {
+		return b.raw
+	}
+
+	format := zedpmSyntax.formatBullet
+	if b.syntax != nil {
+		format = b.syntax.formatBullet
+	}
+	return strings.Split(format(b.Text), "\n")
+}
+
+// Category is a heading within a section naming the category of the bullets
+// that follow it, such as "### Added" in Keep a Changelog. The zedpm format
+// does not use these.
+type Category struct {
+	// Name is the name of the category.
+	Name string
+
+	// Line is the line number of the heading when parsed or 0 if the heading
+	// has been added since.
+	Line int
+
+	raw    string
+	parsed string
+	syntax *syntax
+}
+
+// Lines returns the heading line as a single element slice. The heading is
+// returned as parsed unless the name has been changed.
+func (c *Category) Lines() []string {
+	if c.raw != "" && c.Name == c.parsed {
+		return []string{c.raw}
+	}
+	format := kacSyntax.formatCategory
+	if c.syntax != nil && c.syntax.formatCategory != nil {
+		format = c.syntax.formatCategory
+	}
+	return []string{format(c.Name)}
+}
+
+// Raw is a line in a changelog section that is not part of a bullet. These are
+// normally blank lines, but any line that does not fit the format is kept as a
+// Raw line.
+type Raw struct {
+	// Text is the text of the line.
+	Text string
+
+	// Line is the line number of the line when parsed or 0 if the line has
+	// been added since.
+	Line int
+}
+
+// Lines returns the line as a single element slice.
+func (r *Raw) Lines() []string {
+	return []string{r.Text}
+}
+
+// IsBlank returns true if the line is empty.
+func (r *Raw) IsBlank() bool {
+	return r.Text == ""
+}
+
+// Section is a section of a changelog, which starts with a heading naming the
+// version and date of a release or a WIP heading for unreleased changes.
+type Section struct {
+	// Version is the version named in the heading, without the leading "v". It
+	// is empty for a WIP section.
+	Version string
+
+	// Date is the release date named in the heading. It is empty for a WIP
+	// section.
+	Date string
+
+	// Line is the line number of the heading when parsed or 0 if the section
+	// has been added since.
+	Line int
+
+	// Blocks is the content of the section following the heading.
+	Blocks []Block
+
+	doc           *Document
+	heading       string
+	parsedVersion string
+	parsedDate    string
+	parsedHeading bool
+}
+
+// IsWIP returns true if this is a section of unreleased changes.
+func (s *Section) IsWIP() bool {
+	return s.Version == ""
+}
+
+// Heading returns the heading line of the section. The heading is returned as
+// parsed unless the version or date have been changed.
+func (s *Section) Heading() string {
+	if s.parsedHeading && s.Version == s.parsedVersion && s.Date == s.parsedDate {
+		return s.heading
+	}
+
+	return syntaxOf(s.doc).formatHeading(s)
+}
+
+// SetRelease turns this section into the section for the given release.
+func (s *Section) SetRelease(version, date string) {
+	s.Version = strings.TrimPrefix(version, "v")
+	s.Date = date
+}
+
+// Bullets returns the bullets in the section.
+func (s *Section) Bullets() []*Bullet {
+	bullets := make([]*Bullet, 0, len(s.Blocks))
+	for _, b := range s.Blocks {
+		if bullet, isBullet := b.(*Bullet); isBullet {
+			bullets = append(bullets, bullet)
+		}
+	}
+	return bullets
+}
+
+// FindBullet returns the bullet with the given text, ignoring differences in
+// whitespace, or nil if there is no such bullet.
+func (s *Section) FindBullet(text string) *Bullet {
+	text = normalizeSpace(text)
+	for _, b := range s.Bullets() {
+		if normalizeSpace(b.Text) == text {
+			return b
+		}
+	}
+	return nil
+}
+
+// AddBullet adds a new bullet with the given text after the last bullet in the
+// section and returns it. Blank lines are added around the bullet as needed to
+// keep the section well-formed.
+func (s *Section) AddBullet(text string) *Bullet {
+	bullet := &Bullet{Text: normalizeSpace(text), syntax: syntaxOf(s.doc)}
+	s.appendBullet(bullet)
+	return bullet
+}
+
+// appendBullet does the work of AddBullet.
+func (s *Section) appendBullet(bullet *Bullet) {
+	last := -1
+	for i, b := range s.Blocks {
+		if _, isBullet := b.(*Bullet); isBullet {
+			last = i
+		}
+	}
+
+	if last >= 0 {
+		s.insert(last+1, bullet)
+		return
+	}
+
+	// no bullets yet, so add after the blank line following the heading
+	if len(s.Blocks) == 0 || !isBlank(s.Blocks[0]) {
+		s.insert(0, &Raw{})
+	}
+
+	pos := 1
+	s.insert(pos, bullet)
+	if !s.isLast() && (pos+1 >= len(s.Blocks) || !isBlank(s.Blocks[pos+1])) {
+		s.insert(pos+1, &Raw{})
+	}
+}
+
+// RemoveBullet removes the given bullet from the section. It returns false if
+// the bullet is not in this section.
+func (s *Section) RemoveBullet(bullet *Bullet) bool {
+	for i, b := range s.Blocks {
+		if b != Block(bullet) {
+			continue
+		}
+
+		s.Blocks = append(s.Blocks[:i], s.Blocks[i+1:]...)
+
+		// avoid leaving consecutive blank lines behind
+		if i > 0 && i < len(s.Blocks) && isBlank(s.Blocks[i-1]) && isBlank(s.Blocks[i]) {
+			s.Blocks = append(s.Blocks[:i], s.Blocks[i+1:]...)
+		}
+
+		return true
+	}
+	return false
+}
+
+// MoveBullet moves the given bullet so that it has the given index among the
+// bullets of the section.
+func (s *Section) MoveBullet(bullet *Bullet, index int) error {
+	slots := []int{}
+	bullets := []*Bullet{}
+	from := -1
+	for i, b := range s.Blocks {
+		if b, isBullet := b.(*Bullet); isBullet {
+			if b == bullet {
+				from = len(bullets)
+			}
+			slots = append(slots, i)
+			bullets = append(bullets, b)
+		}
+	}
+
+	if from < 0 {
+		return fmt.Errorf("bullet %q is not in this section", bullet.Text)
+	}
+
+	if index < 0 || index >= len(bullets) {
+		return fmt.Errorf("bullet index %d is out of range", index)
+	}
+
+	bullets = append(bullets[:from], bullets[from+1:]...)
+	bullets = append(bullets[:index], append([]*Bullet{bullet}, bullets[index:]...)...)
+	for i, slot := range slots {
+		s.Blocks[slot] = bullets[i]
+	}
+
+	return nil
+}
+
+// insert inserts a block at the given position.
+func (s *Section) insert(pos int, b Block) {
+	s.insertBlocks(pos, b)
+}
+
+// insertBlocks inserts the blocks at the given position.
+func (s *Section) insertBlocks(pos int, blocks ...Block) {
+	s.Blocks = append(s.Blocks[:pos], append(blocks, s.Blocks[pos:]...)...)
+}
+
+// isLast returns true if this is the last section of the document.
+func (s *Section) isLast() bool {
+	return s.doc == nil || len(s.doc.Sections) == 0 || s.doc.Sections[len(s.doc.Sections)-1] == s
+}
+
+// Document is a changelog parsed into sections and bullets. The document
+// records every line read, so writing an unmodified document reproduces the
+// original changelog exactly.
+type Document struct {
+	// Preamble holds any lines found before the first section heading.
+	Preamble []Block
+
+	// Sections holds the sections of the changelog in order.
+	Sections []*Section
+
+	// Epilogue holds the lines that end the changelog after the last section.
+	// In Keep a Changelog, these are the link reference definitions and
+	// anything following them. The zedpm format has no epilogue.
+	Epilogue []Block
+
+	syntax *syntax
+}
+
+// Parse reads a changelog into a Document.
+func Parse(r io.Reader) (*Document, error) {
+	return parse(r, zedpmSyntax)
+}
+
+// parse reads a changelog written with the given syntax into a Document.
+func parse(r io.Reader, syn *syntax) (*Document, error) {
+	doc := &Document{syntax: syn}
+
+	var (
+		blocks  = &doc.Preamble
+		current *Bullet
+		n       = 0
+	)
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		n++
+		line := sc.Text()
+
+		if blocks == &doc.Epilogue {
+			*blocks = append(*blocks, &Raw{Text: line, Line: n})
+			continue
+		}
+
+		if syn.epilogue != nil && syn.epilogue.MatchString(line) {
+			blocks, current = &doc.Epilogue, nil
+			*blocks = append(*blocks, &Raw{Text: line, Line: n})
+			continue
+		}
+
+		if version, date, ok := syn.heading(line); ok {
+			s := &Section{
+				Version:       version,
+				Date:          date,
+				Line:          n,
+				doc:           doc,
+				heading:       line,
+				parsedVersion: version,
+				parsedDate:    date,
+				parsedHeading: true,
+			}
+			doc.Sections = append(doc.Sections, s)
+			blocks, current = &s.Blocks, nil
+			continue
+		}
+
+		if syn.category != nil {
+			if m := syn.category.FindStringSubmatch(line); m != nil {
+				current = nil
+				*blocks = append(*blocks, &Category{Name: m[1], Line: n, raw: line, parsed: m[1], syntax: syn})
+				continue
+			}
+		}
+
+		if m := syn.bullet.FindStringSubmatch(line); m != nil {
+			current = &Bullet{
+				Text:   strings.TrimSpace(m[1]),
+				Line:   n,
+				raw:    []string{line},
+				syntax: syn,
+			}
+			current.parsed = current.Text
+			*blocks = append(*blocks, current)
+			continue
+		}
+
+		if m := syn.continuation.FindStringSubmatch(line); m != nil && current != nil && strings.TrimSpace(m[1]) != "" {
+			current.raw = append(current.raw, line)
+			current.Text += " " + strings.TrimSpace(m[1])
+			current.parsed = current.Text
+			continue
+		}
+
+		current = nil
+		*blocks = append(*blocks, &Raw{Text: line, Line: n})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// WIP returns the WIP section or nil if the changelog does not have one.
+func (d *Document) WIP() *Section {
+	for _, s := range d.Sections {
+		if s.IsWIP() {
+			return s
+		}
+	}
+	return nil
+}
+
+// AddWIP adds an empty WIP section to the top of the changelog and returns it.
+// If there is already a WIP section, that section is returned instead.
+func (d *Document) AddWIP() *Section {
+	if s := d.WIP(); s != nil {
+		return s
+	}
+
+	s := &Section{doc: d}
+	if len(d.Sections) > 0 || len(d.Epilogue) > 0 {
+		s.Blocks = []Block{&Raw{}}
+	}
+
+	d.Sections = append([]*Section{s}, d.Sections...)
+	return s
+}
+
+// Section returns the section for the given version, which may be given with
+// or without the leading "v". If the version is empty, the first section is
+// returned. It returns nil if there is no such section.
+func (d *Document) Section(version string) *Section {
+	if version == "" {
+		if len(d.Sections) == 0 {
+			return nil
+		}
+		return d.Sections[0]
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	for _, s := range d.Sections {
+		if !s.IsWIP() && s.Version == version {
+			return s
+		}
+	}
+	return nil
+}
+
+// LatestRelease returns the first section that is not a WIP section or nil if
+// there is none.
+func (d *Document) LatestRelease() *Section {
+	for _, s := range d.Sections {
+		if !s.IsWIP() {
+			return s
+		}
+	}
+	return nil
+}
+
+// Lines returns every line of the changelog in order with the role it plays.
+// Lines are numbered from the start of the document as it is now, which is
+// different from the numbers recorded when parsed if the document has been
+// edited.
+func (d *Document) Lines() []Line {
+	lines := []Line{}
+	add := func(text string, kind LineKind) {
+		lines = append(lines, Line{Number: len(lines) + 1, Text: text, Kind: kind})
+	}
+
+	addBlocks := func(blocks []Block) {
+		for _, b := range blocks {
+			switch b := b.(type) {
+			case *Bullet:
+				for i, line := range b.Lines() {
+					if i == 0 {
+						add(line, LineBullet)
+					} else {
+						add(line, LineContinuation)
+					}
+				}
+			case *Category:
+				add(b.Lines()[0], LineCategory)
+			case *Raw:
+				if b.IsBlank() {
+					add(b.Text, LineBlank)
+				} else {
+					add(b.Text, LineOther)
+				}
+			}
+		}
+	}
+
+	addBlocks(d.Preamble)
+	for _, s := range d.Sections {
+		add(s.Heading(), LineHeading)
+		addBlocks(s.Blocks)
+	}
+	addBlocks(d.Epilogue)
+
+	return lines
+}
+
+// WriteTo writes the changelog to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, line := range d.Lines() {
+		n, err := fmt.Fprintln(w, line.Text)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// String returns the changelog as a string.
+func (d *Document) String() string {
+	buf := &strings.Builder{}
+	_, _ = d.WriteTo(buf)
+	return buf.String()
+}
+
+// isBlank returns true if the block is a blank line.
+func isBlank(b Block) bool {
+	r, isRaw := b.(*Raw)
+	return isRaw && r.IsBlank()
+}
+
+// normalizeSpace collapses all runs of whitespace into a single space and
+// trims whitespace from the ends.
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// RollUpPreReleases moves the bullets of every section for a pre-release of
+// the given version into the section for the given version and removes the
+// pre-release sections. For example, rolling up 1.2.0 moves the bullets of
+// 1.2.0-rc.2 and 1.2.0-rc.1 into 1.2.0. Bullets already present are not
+// duplicated. In a format with categories, each bullet is moved to the same
+// category of the given version. It returns the number of sections removed.
+func (d *Document) RollUpPreReleases(version string) (int, error) {
+	target := d.Section(version)
+	if target == nil {
+		return 0, fmt.Errorf("a change log section for version %s was not found", version)
+	}
+
+	final, err := ParseVersion(target.Version)
+	if err != nil {
+		return 0, err
+	}
+
+	if final.PreRelease != "" {
+		return 0, fmt.Errorf("cannot roll up pre-releases into pre-release %s", target.Version)
+	}
+
+	keep := make([]*Section, 0, len(d.Sections))
+	removed := 0
+	for _, s := range d.Sections {
+		if s.IsWIP() || s == target {
+			keep = append(keep, s)
+			continue
+		}
+
+		v, err := ParseVersion(s.Version)
+		if err != nil || v.PreRelease == "" ||
+			v.Major != final.Major || v.Minor != final.Minor || v.Patch != final.Patch {
+			keep = append(keep, s)
+			continue
+		}
+
+		if syntaxOf(d).category != nil {
+			kacRollUp(target, s)
+		} else {
+			for _, b := range s.Bullets() {
+				if target.FindBullet(b.Text) == nil {
+					target.appendBullet(b)
+				}
+			}
+		}
+		removed++
+	}
+
+	d.Sections = keep
+	return removed, nil
+}
@@ -0,0 +1,141 @@
+package changes
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChangelog = `WIP  TBD
+
+ * First change.
+ * A long change that has been wrapped
+   onto a second line.
+ * Third change.
+
+v0.2.0  2023-02-01
+
+ * Released change.
+junk line
+
+v0.1.0  2023-01-01
+
+ * Initial release.
+`
+
+func TestParseRoundTrip(t *testing.T) {
+	doc, err := Parse(strings.NewReader(testChangelog))
+	require.NoError(t, err)
+	assert.Equal(t, testChangelog, doc.String())
+
+	require.Len(t, doc.Sections, 3)
+	wip := doc.WIP()
+	require.NotNil(t, wip)
+	assert.Equal(t, 1, wip.Line)
+
+	bullets := wip.Bullets()
+	require.Len(t, bullets, 3)
+	assert.Equal(t, "A long change that has been wrapped onto a second line.", bullets[1].Text)
+	assert.Equal(t, 4, bullets[1].Line)
+
+	s := doc.Section("v0.2.0")
+	require.NotNil(t, s)
+	assert.Equal(t, "2023-02-01", s.Date)
+	assert.Equal(t, 8, s.Line)
+
+	v, err := Zedpm{}.LatestVersion(strings.NewReader(testChangelog))
+	require.NoError(t, err)
+	assert.Equal(t, "0.2.0", v.String())
+
+	short := "v1.2  2023-01-01\n\n * Short version.\n"
+	assert.NoError(t, NewLinter(strings.NewReader(short), CheckStandard).Check())
+	v, err = Zedpm{}.LatestVersion(strings.NewReader(short))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", v.String())
+
+	changes, err := os.ReadFile("../../Changes.md")
+	require.NoError(t, err)
+	doc, err = Parse(strings.NewReader(string(changes)))
+	require.NoError(t, err)
+	assert.Equal(t, string(changes), doc.String())
+}
+
+func TestDocumentEdit(t *testing.T) {
+	doc, err := Parse(strings.NewReader(testChangelog))
+	require.NoError(t, err)
+
+	wip := doc.WIP()
+	bullets := wip.Bullets()
+	assert.True(t, wip.RemoveBullet(bullets[0]))
+	require.NoError(t, wip.MoveBullet(bullets[2], 0))
+	wip.AddBullet("Added   change.")
+	assert.NotNil(t, wip.FindBullet("Added change."))
+
+	wip.SetRelease("0.3.0", "2023-03-01")
+
+	assert.True(t, strings.HasPrefix(doc.String(), `v0.3.0  2023-03-01
+
+ * Third change.
+ * A long change that has been wrapped
+   onto a second line.
+ * Added change.
+
+v0.2.0  2023-02-01
+`))
+
+	doc, err = Parse(strings.NewReader("WIP  TBD\n\n * Only.\n\nv0.1.0  2023-01-01\n\n * Initial release.\n"))
+	require.NoError(t, err)
+	wip = doc.WIP()
+	assert.True(t, wip.RemoveBullet(wip.Bullets()[0]))
+	assert.Equal(t, "WIP  TBD\n\nv0.1.0  2023-01-01\n\n * Initial release.\n", doc.String())
+	assert.NoError(t, NewLinter(strings.NewReader(doc.String()), CheckPreRelease).Check())
+}
+
+func TestLinterPositions(t *testing.T) {
+	err := NewLinter(strings.NewReader(testChangelog), CheckStandard).Check()
+	require.Error(t, err)
+	assert.Equal(t, Failures{{11, `badly formatted line: it must be blank, start with a space or bullet (" * "), or a heading of the form "version  date"`}},
+		err.(*Error).Failures)
+}
+
+func TestRollUpPreReleases(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`v1.2.0  2023-03-01
+
+ * Final change.
+
+v1.2.0-rc.2  2023-02-15
+
+ * Second candidate change.
+ * Final change.
+
+v1.2.0-rc.1  2023-02-01
+
+ * First candidate change.
+
+v1.1.0  2023-01-01
+
+ * Previous release.
+`))
+	require.NoError(t, err)
+
+	n, err := doc.RollUpPreReleases("v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `v1.2.0  2023-03-01
+
+ * Final change.
+ * Second candidate change.
+ * First candidate change.
+
+v1.1.0  2023-01-01
+
+ * Previous release.
+`, doc.String())
+	assert.NoError(t, NewLinter(strings.NewReader(doc.String()), CheckRelease).Check())
+
+	_, err = doc.RollUpPreReleases("1.3.0")
+	assert.Error(t, err)
+}
@@ -1,12 +1,10 @@
 package changes
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 )
 
 // ExtractSection will return a reader that will output the bullets that are
@@ -19,46 +17,33 @@ func ExtractSection(fn string, vstring string) (io.Reader, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer r.Close()
 
-	var (
-		vprefix = vstring + "  "
-		sc      = bufio.NewScanner(r)
-		started = false
-		blanks  = 0
-		buf     = &bytes.Buffer{}
-	)
+	return extractSection(r, vstring)
+}
 
-	// detect first section if no section selected
-	if vstring == "" {
-		vprefix = ""
+// extractSection performs the work of ExtractSection on an io.Reader.
+func extractSection(r io.Reader, vstring string) (io.Reader, error) {
+	doc, err := Parse(r)
+	if err != nil {
+		return nil, err
 	}
 
-	for sc.Scan() {
-		line := sc.Text()
-		if !started && strings.HasPrefix(line, vprefix) {
-			started = true
-			continue
-		}
-
-		if !started {
-			continue
-		}
+	s := doc.Section(vstring)
+	if s == nil {
+		return nil, fmt.Errorf("a change log section for version %s was not found", vstring)
+	}
 
-		if line == "" {
-			blanks++
-			continue
-		}
+	buf := &bytes.Buffer{}
+	for _, b := range s.Blocks {
+		for _, line := range b.Lines() {
+			if line == "" {
+				continue
+			}
 
-		if blanks >= 2 {
-			break
+			buf.WriteString(line)
+			buf.WriteRune('\n')
 		}
-
-		buf.WriteString(line)
-		buf.WriteRune('\n')
-	}
-
-	if !started {
-		return nil, fmt.Errorf("a change log section for version %s was not found", vstring)
 	}
 
 	return buf, nil
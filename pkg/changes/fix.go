@@ -0,0 +1,133 @@
+package changes
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Fixer is implemented by changelog formats that are able to automatically
+// repair problems found by Lint.
+type Fixer interface {
+	// Fix copies the changelog from r to w, repairing every problem it can.
+	// It returns an *Error describing the problems that remain, if any.
+	Fix(r io.Reader, w io.Writer, mode CheckMode) error
+}
+
+// Verify that Zedpm is a Fixer.
+var _ Fixer = Zedpm{}
+
+var (
+	looseBullet       = regexp.MustCompile(`^\s*[-*+]\s+(\S.*)$`) // bullets with the wrong marker or indent
+	looseContinuation = regexp.MustCompile(`^\s+(\S.*)$`)         // continuations with the wrong indent
+)
+
+// Fix copies the changelog from r to w, repairing the problems found by the
+// Linter that can be repaired mechanically:
+//
+//   - trailing whitespace is removed from every line,
+//   - bullets using "-", "+", or a differently indented "*" are rewritten to
+//     start with " * ",
+//   - continuation lines with the wrong indent are rejoined with the bullet they
+//     follow and the bullet is rewrapped,
+//   - a WIP section found after the first section is moved to the top, and
+//   - blank lines are added or removed so that exactly one blank line follows
+//     each heading and separates each section from the next.
+//
+// Any remaining problems are returned as an *Error after the repaired
+// changelog has been written.
+func (Zedpm) Fix(r io.Reader, w io.Writer, mode CheckMode) error {
+	buf := &strings.Builder{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		buf.WriteString(strings.TrimRightFunc(sc.Text(), unicode.IsSpace))
+		buf.WriteRune('\n')
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	doc, err := Parse(strings.NewReader(buf.String()))
+	if err != nil {
+		return err
+	}
+
+	FixDocument(doc)
+
+	if _, err := doc.WriteTo(w); err != nil {
+		return err
+	}
+
+	return NewLinter(nil, mode).CheckDocument(doc)
+}
+
+// FixDocument performs the structural repairs described for Zedpm.Fix on the
+// given document, apart from the removal of trailing whitespace.
+func FixDocument(doc *Document) {
+	// move a misplaced WIP section to the top
+	if wip := doc.WIP(); wip != nil && doc.Sections[0] != wip {
+		secs := []*Section{wip}
+		for _, s := range doc.Sections {
+			if s != wip {
+				secs = append(secs, s)
+			}
+		}
+		doc.Sections = secs
+	}
+
+	doc.Preamble = fixBlocks(doc.Preamble)
+	for i, s := range doc.Sections {
+		blocks := fixBlocks(s.Blocks)
+
+		// remove all blank lines, then put back the ones that belong
+		content := make([]Block, 0, len(blocks)+2)
+		content = append(content, &Raw{})
+		for _, b := range blocks {
+			if !isBlank(b) {
+				content = append(content, b)
+			}
+		}
+
+		isLast := i == len(doc.Sections)-1
+		switch {
+		case len(content) == 1 && isLast:
+			content = content[:0]
+		case len(content) > 1 && !isLast:
+			content = append(content, &Raw{})
+		}
+
+		s.Blocks = content
+	}
+}
+
+// fixBlocks repairs misformatted bullets and continuation lines in the given
+// blocks.
+func fixBlocks(blocks []Block) []Block {
+	out := make([]Block, 0, len(blocks))
+	var last *Bullet
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case *Bullet:
+			last = b
+			out = append(out, b)
+			continue
+		case *Raw:
+			if m := looseBullet.FindStringSubmatch(b.Text); m != nil {
+				last = &Bullet{Text: normalizeSpace(m[1]), Line: b.Line}
+				out = append(out, last)
+				continue
+			}
+
+			if m := looseContinuation.FindStringSubmatch(b.Text); m != nil && last != nil {
+				last.Text = normalizeSpace(last.Text + " " + m[1])
+				continue
+			}
+
+			last = nil
+			out = append(out, b)
+		}
+	}
+	return out
+}
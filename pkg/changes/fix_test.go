@@ -0,0 +1,41 @@
+package changes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZedpmFix(t *testing.T) {
+	in := "v0.1.0  2023-01-01\n" +
+		" * Initial release.  \n" +
+		"WIP  TBD\n" +
+		"\n" +
+		"\n" +
+		"- A change that was written with the wrong bullet.\n" +
+		"  and continued with the wrong indent.\n" +
+		"\n" +
+		" * Another change.\n" +
+		"  \n"
+
+	w := &bytes.Buffer{}
+	err := Zedpm{}.Fix(strings.NewReader(in), w, CheckPreRelease)
+	require.NoError(t, err)
+	assert.Equal(t, "WIP  TBD\n"+
+		"\n"+
+		" * A change that was written with the wrong bullet. and continued with the\n"+
+		"   wrong indent.\n"+
+		" * Another change.\n"+
+		"\n"+
+		"v0.1.0  2023-01-01\n"+
+		"\n"+
+		" * Initial release.\n", w.String())
+
+	w = &bytes.Buffer{}
+	err = Zedpm{}.Fix(strings.NewReader("v0.1.0  2023-01-01\n\n * Initial.\nnot a bullet\n"), w, CheckRelease)
+	require.Error(t, err)
+	assert.Len(t, err.(*Error).Failures, 1)
+}
@@ -0,0 +1,114 @@
+package changes
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Format is the interface implemented by each supported changelog format.
+type Format interface {
+	// Lint checks the changelog read from r for problems. It returns an
+	// *Error describing every problem found or nil if there are none.
+	Lint(r io.Reader, mode CheckMode) error
+
+	// ExtractSection returns the entries recorded for the given version. The
+	// version may be given with or without a leading "v". If the version is
+	// empty, the entries of the most recent section are returned.
+	ExtractSection(r io.Reader, version string) (io.Reader, error)
+
+	// FixupForRelease copies the changelog from r to w, changing the section
+	// of unreleased changes into a section for the given version and date.
+	FixupForRelease(r io.Reader, w io.Writer, version string, date time.Time) error
+
+	// AddUnreleased copies the changelog from r to w, adding the given
+	// entries to the section of unreleased changes. The section is created if
+	// it does not exist. Entries already present are not duplicated.
+	AddUnreleased(r io.Reader, w io.Writer, entries []Entry) error
+
+	// Unreleased returns the entries in the section of unreleased changes. It
+	// returns no entries if there is no such section.
+	Unreleased(r io.Reader) ([]Entry, error)
+
+	// LatestVersion returns the version of the most recent release recorded in
+	// the changelog or nil if there is none.
+	LatestVersion(r io.Reader) (*semver.Version, error)
+}
+
+// RollUpper is implemented by changelog formats that are able to merge the
+// sections for the pre-releases of a version into the section for the final
+// release of that version.
+type RollUpper interface {
+	// RollUp copies the changelog from r to w, moving the entries of every
+	// pre-release of the given version into the section for the version and
+	// removing the pre-release sections.
+	RollUp(r io.Reader, w io.Writer, version string) error
+}
+
+// Categories of change used to describe an Entry. These are the categories
+// defined by Keep a Changelog.
+const (
+	CategoryAdded      = "Added"
+	CategoryChanged    = "Changed"
+	CategoryDeprecated = "Deprecated"
+	CategoryRemoved    = "Removed"
+	CategoryFixed      = "Fixed"
+	CategorySecurity   = "Security"
+)
+
+// Categories lists the categories of change in the order they are normally
+// presented.
+var Categories = []string{
+	CategoryAdded,
+	CategoryChanged,
+	CategoryDeprecated,
+	CategoryRemoved,
+	CategoryFixed,
+	CategorySecurity,
+}
+
+// ParseCategory returns the category matching the given name, ignoring case.
+func ParseCategory(name string) (string, error) {
+	for _, c := range Categories {
+		if strings.EqualFold(c, name) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown change category %q, expected one of: %s", name, strings.Join(Categories, ", "))
+}
+
+// Entry is a single change to record in a changelog.
+type Entry struct {
+	// Category is the kind of change. It may be empty if the change is not
+	// categorized.
+	Category string
+
+	// Text describes the change.
+	Text string
+}
+
+// Formats maps the name of each supported changelog format to its
+// implementation.
+var Formats = map[string]Format{
+	"zedpm":          Zedpm{},
+	"keepachangelog": KeepAChangelog{},
+}
+
+// GetFormat returns the named changelog format or an error if there is no
+// format by that name.
+func GetFormat(name string) (Format, error) {
+	if f, ok := Formats[name]; ok {
+		return f, nil
+	}
+
+	names := make([]string, 0, len(Formats))
+	for n := range Formats {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown changelog format %q, expected one of: %s", name, strings.Join(names, ", "))
+}
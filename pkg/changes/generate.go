@@ -0,0 +1,120 @@
+package changes
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zostay/zedpm/pkg/conventional"
+)
+
+const (
+	// WIPHeading is the heading used for the section of unreleased changes.
+	WIPHeading = "WIP  TBD"
+
+	// BulletWidth is the column at which bullets are wrapped.
+	BulletWidth = 79
+
+	bulletStart        = " * "
+	bulletContinuation = "   "
+)
+
+// FormatBullet formats the given text as a bullet, wrapping the text onto
+// continuation lines so that no line exceeds BulletWidth columns, unless a
+// single word is too long to fit. The returned string does not end with a
+// newline.
+func FormatBullet(text string) string {
+	buf := &strings.Builder{}
+	buf.WriteString(bulletStart)
+	col := len(bulletStart)
+	lineStart := true
+	for _, word := range strings.Fields(text) {
+		wl := utf8.RuneCountInString(word)
+		if !lineStart && col+1+wl > BulletWidth {
+			buf.WriteString("\n" + bulletContinuation)
+			col = len(bulletContinuation)
+			lineStart = true
+		}
+
+		if !lineStart {
+			buf.WriteRune(' ')
+			col++
+		}
+
+		buf.WriteString(word)
+		col += wl
+		lineStart = false
+	}
+	return buf.String()
+}
+
+// capitalize returns the string with the first letter in upper case.
+func capitalize(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// EntriesFromCommits returns changelog entries describing the given
+// conventional commits. Breaking changes are listed first, then features, then
+// fixes and performance improvements. Commits of any other type are omitted
+// unless they are breaking. Within each group, the entries are kept in the
+// order of the given commits.
+func EntriesFromCommits(commits []*conventional.Commit) []Entry {
+	var breaking, features, fixes []Entry
+	for _, c := range commits {
+		desc := capitalize(c.Description)
+		switch {
+		case c.Breaking:
+			breaking = append(breaking, Entry{CategoryChanged, "BREAKING: " + desc})
+		case c.IsFeature():
+			features = append(features, Entry{CategoryAdded, desc})
+		case c.IsFix():
+			fixes = append(fixes, Entry{CategoryFixed, desc})
+		case c.Type == "perf":
+			fixes = append(fixes, Entry{CategoryChanged, "Performance: " + desc})
+		}
+	}
+
+	out := make([]Entry, 0, len(breaking)+len(features)+len(fixes))
+	out = append(out, breaking...)
+	out = append(out, features...)
+	out = append(out, fixes...)
+	return out
+}
+
+// InsertWIP copies the changelog from r to w, adding the given bullets to the
+// end of the WIP section. If there is no WIP section, one is added at the top.
+// Bullets whose text already appears in the WIP section are not added again. If
+// there are no bullets to add, the changelog is copied unchanged.
+func InsertWIP(r io.Reader, w io.Writer, bullets []string) error {
+	doc, err := Parse(r)
+	if err != nil {
+		return err
+	}
+
+	AddWIPBullets(doc, bullets)
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// AddWIPBullets adds the given bullets to the end of the WIP section of the
+// document, as described for InsertWIP. It returns the number of bullets added.
+func AddWIPBullets(doc *Document, bullets []string) int {
+	wip := doc.WIP()
+	added := 0
+	for _, bullet := range bullets {
+		if wip != nil && wip.FindBullet(bullet) != nil {
+			continue
+		}
+
+		if wip == nil {
+			wip = doc.AddWIP()
+		}
+
+		wip.AddBullet(bullet)
+		added++
+	}
+	return added
+}
@@ -0,0 +1,83 @@
+package changes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/conventional"
+)
+
+func TestFormatBullet(t *testing.T) {
+	assert.Equal(t, " * Short.", FormatBullet("Short."))
+	assert.Equal(t,
+		" * This is a long line that will end up wrapped in the file because it is long\n"+
+			"   and long lines are tedious if you have to scroll horizontally.",
+		FormatBullet("This is a long line that will end up wrapped in the file because it is long and long lines are tedious if you have to scroll horizontally."))
+}
+
+func TestInsertWIP(t *testing.T) {
+	commits := []*conventional.Commit{
+		conventional.Parse("fix: a bug"),
+		conventional.Parse("feat(cmd): a feature"),
+		conventional.Parse("chore: ignored"),
+		conventional.Parse("refactor!: everything"),
+	}
+	entries := EntriesFromCommits(commits)
+	assert.Equal(t, []Entry{
+		{CategoryChanged, "BREAKING: Everything"},
+		{CategoryAdded, "A feature"},
+		{CategoryFixed, "A bug"},
+	}, entries)
+
+	tests := []struct {
+		name, in, out string
+	}{
+		{
+			name: "existing WIP",
+			in:   "WIP  TBD\n\n * Existing.\n * A feature\n\nv0.1.0  2023-01-01\n\n * Initial.\n",
+			out:  "WIP  TBD\n\n * Existing.\n * A feature\n * BREAKING: Everything\n * Fix: A bug\n\nv0.1.0  2023-01-01\n\n * Initial.\n",
+		},
+		{
+			name: "no WIP",
+			in:   "v0.1.0  2023-01-01\n\n * Initial.\n",
+			out:  "WIP  TBD\n\n * BREAKING: Everything\n * A feature\n * Fix: A bug\n\nv0.1.0  2023-01-01\n\n * Initial.\n",
+		},
+		{
+			name: "empty WIP",
+			in:   "WIP  TBD\n\nv0.1.0  2023-01-01\n\n * Initial.\n",
+			out:  "WIP  TBD\n\n * BREAKING: Everything\n * A feature\n * Fix: A bug\n\nv0.1.0  2023-01-01\n\n * Initial.\n",
+		},
+	}
+
+	for _, test := range tests {
+		w := &bytes.Buffer{}
+		err := Zedpm{}.AddUnreleased(strings.NewReader(test.in), w, entries)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.out, w.String(), test.name)
+		assert.NoError(t, NewLinter(w, CheckPreRelease).Check(), test.name)
+	}
+}
+
+func TestZedpmAddUnreleased(t *testing.T) {
+	w := &bytes.Buffer{}
+	err := Zedpm{}.AddUnreleased(strings.NewReader("v0.1.0  2023-01-01\n\n * Initial.\n"), w, []Entry{
+		{CategorySecurity, "Keys are no longer written to the log when the release fails partway through the publish phase."},
+		{CategoryAdded, "Short."},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `WIP  TBD
+
+ * Security: Keys are no longer written to the log when the release fails
+   partway through the publish phase.
+ * Short.
+
+v0.1.0  2023-01-01
+
+ * Initial.
+`, w.String())
+	assert.NoError(t, NewLinter(w, CheckPreRelease).Check())
+}
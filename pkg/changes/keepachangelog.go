@@ -0,0 +1,597 @@
+package changes
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Verify that KeepAChangelog is a Format and a RollUpper.
+var (
+	_ Format    = KeepAChangelog{}
+	_ RollUpper = KeepAChangelog{}
+)
+
+// KeepAChangelog implements the changelog format described at
+// https://keepachangelog.com/. A changelog in this format looks like this:
+//
+//	# Changelog
+//
+//	## [Unreleased]
+//
+//	### Added
+//
+//	- A change for the work in progress.
+//
+//	## [1.0.0] - 2023-03-04
+//
+//	### Fixed
+//
+//	- A change for the latest release.
+//
+//	[Unreleased]: https://github.com/example/project/compare/v1.0.0...HEAD
+//	[1.0.0]: https://github.com/example/project/compare/v0.9.0...v1.0.0
+//
+// Each release is a second-level heading naming the version and date. Changes
+// are listed as bullets grouped under third-level headings naming the category
+// of change. Unreleased changes are kept in a section named "Unreleased" at the
+// top.
+type KeepAChangelog struct{}
+
+// Unreleased is the name of the section of unreleased changes in a Keep a
+// Changelog changelog.
+const Unreleased = "Unreleased"
+
+var (
+	kacVersionHeading  = regexp.MustCompile(`^## \[([^\]]+)\](?: - (\d{4}-\d\d-\d\d))?(?: \[YANKED\])?$`) // "## [version] - date" lines
+	kacCategoryHeading = regexp.MustCompile(`^### (.*)$`)                                                 // "### Category" lines
+	kacBullet          = regexp.MustCompile(`^[-*] (.*)$`)                                                // bullet start lines
+	kacContinuation    = regexp.MustCompile(`^\s+(\S.*)$`)                                                // bullet continuation lines
+	kacLinkReference   = regexp.MustCompile(`^\[([^\]]+)\]: (\S+)$`)                                      // link reference definitions
+	kacCompareLink     = regexp.MustCompile(`^(.*/compare/)(\S+)\.\.\.HEAD$`)                             // compare URLs for unreleased changes
+	kacCompareRange    = regexp.MustCompile(`^(.*/compare/)(\S+)\.\.\.(\S+)$`)                            // compare URLs between two refs
+)
+
+// kacSyntax is the syntax of the Keep a Changelog format. The link reference
+// definitions at the end of the changelog are kept in the epilogue.
+var kacSyntax = &syntax{
+	heading: func(line string) (string, string, bool) {
+		m := kacVersionHeading.FindStringSubmatch(line)
+		if m == nil {
+			return "", "", false
+		}
+
+		if strings.EqualFold(m[1], Unreleased) {
+			return "", m[2], true
+		}
+
+		return m[1], m[2], true
+	},
+	formatHeading: func(s *Section) string {
+		if s.IsWIP() {
+			return "## [" + Unreleased + "]"
+		}
+		return fmt.Sprintf("## [%s] - %s", s.Version, s.Date)
+	},
+	bullet:       kacBullet,
+	continuation: kacContinuation,
+	formatBullet: func(text string) string {
+		return "- " + text
+	},
+	category: kacCategoryHeading,
+	formatCategory: func(name string) string {
+		return "### " + name
+	},
+	epilogue: kacLinkReference,
+}
+
+// ParseKeepAChangelog reads a changelog in the Keep a Changelog format into a
+// Document. The Unreleased section is the WIP section of the document.
+func ParseKeepAChangelog(r io.Reader) (*Document, error) {
+	return parse(r, kacSyntax)
+}
+
+// Lint checks that the changelog is well-formed. During a pre-release check,
+// the first section must be the Unreleased section. During a release check,
+// the Unreleased section, if present, must be empty.
+func (KeepAChangelog) Lint(r io.Reader, mode CheckMode) error {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	status := checkStatus{}
+	n := 0
+	lintBlocks := func(blocks []Block, inSection bool) {
+		for _, b := range blocks {
+			switch b := b.(type) {
+			case *Category:
+				n++
+				if !inSection {
+					status.fail(n, "category heading before first version heading")
+				}
+
+				if c, err := ParseCategory(b.Name); err != nil || c != b.Name {
+					status.failf(n, "unknown change category %q, expected one of: %s",
+						b.Name, strings.Join(Categories, ", "))
+				}
+
+			case *Bullet:
+				if !inSection {
+					status.fail(n+1, "change bullet before first version heading")
+				}
+				n += len(b.Lines())
+
+			case *Raw:
+				n++
+				switch {
+				case strings.HasPrefix(b.Text, "## "):
+					status.fail(n, "badly formatted version heading: it must be \"## [version] - date\" or \"## [Unreleased]\"")
+				case whitespaceLine.MatchString(b.Text):
+					status.fail(n, "line looks blank, but has spaces in it")
+				}
+			}
+		}
+	}
+
+	lintBlocks(doc.Preamble, false)
+	for i, s := range doc.Sections {
+		n++
+		if s.IsWIP() {
+			if i > 0 {
+				status.fail(n, "Unreleased section found after a release section")
+			}
+
+			if s.Date != "" {
+				status.fail(n, "Unreleased section must not have a date")
+			}
+
+			if mode == CheckRelease && len(s.Bullets()) > 0 {
+				status.fail(n, "Found unreleased changes during release")
+			}
+
+			status.previousLine = n
+			lintBlocks(s.Blocks, true)
+			continue
+		}
+
+		if i == 0 && mode == CheckPreRelease {
+			status.fail(n, "Unreleased section not found during pre-release check")
+		}
+
+		version, err := ParseVersion(s.Version)
+		if err != nil {
+			status.fail(n, "Unable to parse version number in heading")
+			status.previousLine = n
+			lintBlocks(s.Blocks, true)
+			continue
+		}
+
+		if s.Date == "" {
+			status.fail(n, "version heading is missing the release date")
+		}
+
+		// version and date are in descending order in a changelog
+
+		if status.previousVersion != nil && status.previousVersion.LessThan(*version) {
+			status.failf(n, "version error %s < %s from line %d",
+				version, status.previousVersion, status.previousLine)
+		}
+
+		if s.Date != "" && status.previousDate != "" && status.previousDate < s.Date {
+			status.failf(n, "date error %s < %s from line %d",
+				s.Date, status.previousDate, status.previousLine)
+		}
+
+		status.previousVersion = version
+		if s.Date != "" {
+			status.previousDate = s.Date
+		}
+		status.previousLine = n
+
+		lintBlocks(s.Blocks, true)
+	}
+	lintBlocks(doc.Epilogue, len(doc.Sections) > 0)
+
+	if len(doc.Sections) == 0 && mode == CheckPreRelease {
+		status.fail(1, "Unreleased section not found during pre-release check")
+	}
+
+	if len(status.Failures) > 0 {
+		return &Error{status.Failures}
+	}
+
+	return nil
+}
+
+// ExtractSection returns the body of the section for the given version,
+// including the category headings. If the version is empty, the body of the
+// first section with any changes is returned.
+func (KeepAChangelog) ExtractSection(r io.Reader, version string) (io.Reader, error) {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return nil, err
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	for _, s := range doc.Sections {
+		switch {
+		case version == "" && len(s.Bullets()) == 0:
+			continue
+		case strings.EqualFold(version, Unreleased) && !s.IsWIP():
+			continue
+		case version != "" && !strings.EqualFold(version, Unreleased) &&
+			(s.IsWIP() || s.Version != version):
+			continue
+		}
+
+		buf := &strings.Builder{}
+		for _, b := range kacBody(s) {
+			for _, line := range b.Lines() {
+				buf.WriteString(line)
+				buf.WriteRune('\n')
+			}
+		}
+		return strings.NewReader(buf.String()), nil
+	}
+
+	return nil, fmt.Errorf("a change log section for version %s was not found", version)
+}
+
+// kacBody returns the blocks of the section with leading and trailing blank
+// lines removed.
+func kacBody(s *Section) []Block {
+	body := s.Blocks
+	for len(body) > 0 && kacIsBlank(body[0]) {
+		body = body[1:]
+	}
+	for len(body) > 0 && kacIsBlank(body[len(body)-1]) {
+		body = body[:len(body)-1]
+	}
+	return body
+}
+
+// kacIsBlank returns true if the block is a line that is empty or contains
+// only whitespace.
+func kacIsBlank(b Block) bool {
+	r, isRaw := b.(*Raw)
+	return isRaw && strings.TrimSpace(r.Text) == ""
+}
+
+// FixupForRelease turns the Unreleased section into the section for the given
+// version and adds a new, empty Unreleased section above it. If the changelog
+// ends with a link reference comparing the last release to HEAD, it is updated
+// and a link reference for the new version is added.
+func (KeepAChangelog) FixupForRelease(
+	r io.Reader,
+	w io.Writer,
+	version string,
+	date time.Time,
+) error {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	if len(doc.Sections) == 0 || !doc.Sections[0].IsWIP() {
+		return fmt.Errorf("no %s section found in changelog", Unreleased)
+	}
+
+	release := doc.Sections[0]
+	release.SetRelease(version, date.Format("2006-01-02"))
+	doc.AddWIP()
+
+	epilogue := make([]Block, 0, len(doc.Epilogue)+1)
+	for _, b := range doc.Epilogue {
+		epilogue = append(epilogue, b)
+
+		raw, isRaw := b.(*Raw)
+		if !isRaw {
+			continue
+		}
+
+		m := kacLinkReference.FindStringSubmatch(raw.Text)
+		if m == nil || !strings.EqualFold(m[1], Unreleased) {
+			continue
+		}
+
+		cm := kacCompareLink.FindStringSubmatch(m[2])
+		if cm == nil {
+			continue
+		}
+
+		base, prev := cm[1], cm[2]
+		tag := release.Version
+		if strings.HasPrefix(prev, "v") {
+			tag = "v" + release.Version
+		}
+
+		raw.Text = fmt.Sprintf("[%s]: %s%s...HEAD", m[1], base, tag)
+		epilogue = append(epilogue,
+			&Raw{Text: fmt.Sprintf("[%s]: %s%s...%s", release.Version, base, prev, tag)})
+	}
+	doc.Epilogue = epilogue
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// RollUp moves the bullets of the sections for pre-releases of the given
+// version into the matching categories of the section for that version and
+// removes the pre-release sections along with their link references. If the
+// link reference of the version compares it to one of the removed
+// pre-releases, it is changed to compare from the release before them.
+func (KeepAChangelog) RollUp(r io.Reader, w io.Writer, version string) error {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	before := doc.Sections
+	if _, err := doc.RollUpPreReleases(version); err != nil {
+		return err
+	}
+
+	kept := map[*Section]struct{}{}
+	for _, s := range doc.Sections {
+		kept[s] = struct{}{}
+	}
+
+	removed := map[string]string{}
+	for _, s := range before {
+		if _, isKept := kept[s]; !isKept {
+			removed[s.Version] = ""
+		}
+	}
+
+	var targetLink *Raw
+	target := doc.Section(version)
+	epilogue := make([]Block, 0, len(doc.Epilogue))
+	for _, b := range doc.Epilogue {
+		raw, isRaw := b.(*Raw)
+		if !isRaw {
+			epilogue = append(epilogue, b)
+			continue
+		}
+
+		m := kacLinkReference.FindStringSubmatch(raw.Text)
+		if m == nil {
+			epilogue = append(epilogue, b)
+			continue
+		}
+
+		name := strings.TrimPrefix(m[1], "v")
+		if _, isRemoved := removed[name]; isRemoved {
+			if cm := kacCompareRange.FindStringSubmatch(m[2]); cm != nil {
+				removed[name] = cm[2]
+			}
+			continue
+		}
+
+		if name == target.Version {
+			targetLink = raw
+		}
+		epilogue = append(epilogue, b)
+	}
+	doc.Epilogue = epilogue
+
+	if targetLink != nil {
+		m := kacLinkReference.FindStringSubmatch(targetLink.Text)
+		if cm := kacCompareRange.FindStringSubmatch(m[2]); cm != nil {
+			base, prev, tag := cm[1], cm[2], cm[3]
+			for i := 0; i < len(removed); i++ {
+				next, isRemoved := removed[strings.TrimPrefix(prev, "v")]
+				if !isRemoved || next == "" {
+					break
+				}
+				prev = next
+			}
+
+			targetLink.Text = fmt.Sprintf("[%s]: %s%s...%s", m[1], base, prev, tag)
+		}
+	}
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// kacRollUp adds the bullets of the from section to the same categories of the
+// into section. Bullets already present are skipped and bullets not under a
+// known category are added as changes.
+func kacRollUp(into, from *Section) {
+	var (
+		ci      = categoryIndex(CategoryChanged)
+		added   = map[string]struct{}{}
+		bullets = map[int][]Block{}
+	)
+	for _, b := range from.Blocks {
+		switch b := b.(type) {
+		case *Category:
+			ci = categoryIndex(CategoryChanged)
+			if category, err := ParseCategory(b.Name); err == nil {
+				ci = categoryIndex(category)
+			}
+		case *Bullet:
+			text := normalizeSpace(b.Text)
+			if _, exists := added[text]; exists || into.FindBullet(text) != nil {
+				continue
+			}
+			added[text] = struct{}{}
+			bullets[ci] = append(bullets[ci], b)
+		}
+	}
+
+	for ci := range Categories {
+		if len(bullets[ci]) > 0 {
+			kacAddToCategory(into, ci, bullets[ci])
+		}
+	}
+}
+
+// AddUnreleased adds a bullet for each entry to the matching category of the
+// Unreleased section. Entries without a category are added as changes.
+func (KeepAChangelog) AddUnreleased(r io.Reader, w io.Writer, entries []Entry) error {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	if len(doc.Sections) == 0 || !doc.Sections[0].IsWIP() {
+		wip := &Section{doc: doc, Blocks: []Block{&Raw{}}}
+		doc.Sections = append([]*Section{wip}, doc.Sections...)
+		if n := len(doc.Preamble); n > 0 && !isBlank(doc.Preamble[n-1]) {
+			doc.Preamble = append(doc.Preamble, &Raw{})
+		}
+	}
+
+	wip := doc.Sections[0]
+	added := map[string]struct{}{}
+	bullets := map[string][]Block{}
+	for _, e := range entries {
+		text := normalizeSpace(e.Text)
+		if _, exists := added[text]; exists || wip.FindBullet(text) != nil {
+			continue
+		}
+		added[text] = struct{}{}
+
+		category := e.Category
+		if category == "" {
+			category = CategoryChanged
+		}
+
+		bullets[category] = append(bullets[category], &Bullet{Text: text, syntax: kacSyntax})
+	}
+
+	for ci, category := range Categories {
+		if len(bullets[category]) == 0 {
+			continue
+		}
+
+		kacAddToCategory(wip, ci, bullets[category])
+	}
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// kacAddToCategory adds the given bullets to the end of the category at the
+// given index into Categories within the section. The category heading is
+// added if it is not present.
+func kacAddToCategory(s *Section, ci int, bullets []Block) {
+	heading := &Category{Name: Categories[ci], syntax: kacSyntax}
+
+	// a blank line must separate the end of the section from what follows
+	followed := s != s.doc.Sections[len(s.doc.Sections)-1] || len(s.doc.Epilogue) > 0
+
+	// look for the category or the category it must precede
+	for i, b := range s.Blocks {
+		c, isCategory := b.(*Category)
+		if !isCategory {
+			continue
+		}
+
+		if c.Name == Categories[ci] {
+			end := i + 1
+			for end < len(s.Blocks) {
+				if _, isCategory := s.Blocks[end].(*Category); isCategory {
+					break
+				}
+				end++
+			}
+
+			last := -1
+			for j := i + 1; j < end; j++ {
+				if _, isBullet := s.Blocks[j].(*Bullet); isBullet {
+					last = j
+				}
+			}
+
+			if last >= 0 {
+				s.insertBlocks(last+1, bullets...)
+				return
+			}
+
+			add := append([]Block{&Raw{}}, bullets...)
+			if (i+1 < len(s.Blocks) && !isBlank(s.Blocks[i+1])) || (i+1 == len(s.Blocks) && followed) {
+				add = append(add, &Raw{})
+			}
+			s.insertBlocks(i+1, add...)
+			return
+		}
+
+		if cat, err := ParseCategory(c.Name); err == nil && categoryIndex(cat) > ci {
+			add := append([]Block{heading, &Raw{}}, bullets...)
+			s.insertBlocks(i, append(add, &Raw{})...)
+			return
+		}
+	}
+
+	// add to the end of the section
+	end := len(s.Blocks)
+	for end > 0 && kacIsBlank(s.Blocks[end-1]) {
+		end--
+	}
+
+	add := append([]Block{&Raw{}, heading, &Raw{}}, bullets...)
+	if end == len(s.Blocks) && followed {
+		add = append(add, &Raw{})
+	}
+	s.insertBlocks(end, add...)
+}
+
+// categoryIndex returns the index of the category in Categories.
+func categoryIndex(category string) int {
+	for i, c := range Categories {
+		if c == category {
+			return i
+		}
+	}
+	return len(Categories)
+}
+
+// Unreleased returns the bullets of the Unreleased section along with the
+// category each is listed under.
+func (KeepAChangelog) Unreleased(r io.Reader) ([]Entry, error) {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Sections) == 0 || !doc.Sections[0].IsWIP() {
+		return nil, nil
+	}
+
+	var (
+		entries  []Entry
+		category string
+	)
+	for _, b := range doc.Sections[0].Blocks {
+		switch b := b.(type) {
+		case *Category:
+			category = b.Name
+		case *Bullet:
+			entries = append(entries, Entry{Category: category, Text: normalizeSpace(b.Text)})
+		}
+	}
+
+	return entries, nil
+}
+
+// LatestVersion returns the version of the first release section.
+func (KeepAChangelog) LatestVersion(r io.Reader) (*semver.Version, error) {
+	doc, err := ParseKeepAChangelog(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := doc.LatestRelease()
+	if s == nil {
+		return nil, nil
+	}
+
+	return ParseVersion(s.Version)
+}
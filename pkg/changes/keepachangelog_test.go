@@ -0,0 +1,179 @@
+package changes
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kacChangelog = `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- A feature.
+
+## [1.1.0] - 2023-02-01
+
+### Fixed
+
+- A bug.
+
+## [1.0.0] - 2023-01-01
+
+### Added
+
+- Initial release.
+
+[Unreleased]: https://github.com/example/project/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/example/project/compare/v1.0.0...v1.1.0
+`
+
+func TestKeepAChangelog(t *testing.T) {
+	f := KeepAChangelog{}
+
+	assert.NoError(t, f.Lint(strings.NewReader(kacChangelog), CheckPreRelease))
+	assert.Error(t, f.Lint(strings.NewReader(kacChangelog), CheckRelease))
+
+	v, err := f.LatestVersion(strings.NewReader(kacChangelog))
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", v.String())
+
+	r, err := f.ExtractSection(strings.NewReader(kacChangelog), "v1.1.0")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "### Fixed\n\n- A bug.\n", string(data))
+
+	w := &bytes.Buffer{}
+	err = f.AddUnreleased(strings.NewReader(kacChangelog), w, []Entry{
+		{CategoryFixed, "Another bug"},
+		{CategoryAdded, "A feature."},
+		{CategoryAdded, "Another feature"},
+	})
+	require.NoError(t, err)
+	added := w.String()
+	assert.Contains(t, added, "### Added\n\n- A feature.\n- Another feature\n\n### Fixed\n\n- Another bug\n\n## [1.1.0]")
+	assert.NoError(t, f.Lint(strings.NewReader(added), CheckPreRelease))
+
+	w = &bytes.Buffer{}
+	date := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	err = f.FixupForRelease(strings.NewReader(added), w, "1.2.0", date)
+	require.NoError(t, err)
+	released := w.String()
+	assert.Contains(t, released, "## [Unreleased]\n\n## [1.2.0] - 2023-03-01\n\n### Added\n")
+	assert.Contains(t, released, "[Unreleased]: https://github.com/example/project/compare/v1.2.0...HEAD\n"+
+		"[1.2.0]: https://github.com/example/project/compare/v1.1.0...v1.2.0\n")
+	assert.NoError(t, f.Lint(strings.NewReader(released), CheckRelease))
+}
+
+func TestKeepAChangelogNoUnreleased(t *testing.T) {
+	w := &bytes.Buffer{}
+	err := KeepAChangelog{}.AddUnreleased(
+		strings.NewReader("# Changelog\n\n## [1.0.0] - 2023-01-01\n\n- Initial release.\n"),
+		w, []Entry{{"", "A change"}})
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n### Changed\n\n- A change\n\n## [1.0.0] - 2023-01-01\n\n- Initial release.\n", w.String())
+}
+
+func TestParseKeepAChangelog(t *testing.T) {
+	doc, err := ParseKeepAChangelog(strings.NewReader(kacChangelog))
+	require.NoError(t, err)
+	assert.Equal(t, kacChangelog, doc.String())
+
+	require.Len(t, doc.Sections, 3)
+	assert.True(t, doc.Sections[0].IsWIP())
+	assert.Equal(t, "1.1.0", doc.Sections[1].Version)
+	assert.Equal(t, "2023-02-01", doc.Sections[1].Date)
+	assert.Same(t, doc.Sections[1], doc.LatestRelease())
+	assert.Len(t, doc.Epilogue, 2)
+
+	require.Len(t, doc.Sections[1].Bullets(), 1)
+	assert.Equal(t, "A bug.", doc.Sections[1].Bullets()[0].Text)
+
+	c, isCategory := doc.Sections[1].Blocks[1].(*Category)
+	require.True(t, isCategory)
+	assert.Equal(t, CategoryFixed, c.Name)
+
+	doc.Sections[1].SetRelease("1.1.1", "2023-02-02")
+	doc.Sections[1].AddBullet("Another bug.")
+	assert.Contains(t, doc.String(), "## [1.1.1] - 2023-02-02\n\n### Fixed\n\n- A bug.\n- Another bug.\n\n## [1.0.0]")
+}
+
+func TestKeepAChangelogRollUp(t *testing.T) {
+	w := &bytes.Buffer{}
+	err := KeepAChangelog{}.RollUp(strings.NewReader(`# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2023-03-01
+
+### Fixed
+
+- Final fix.
+
+## [1.2.0-rc.2] - 2023-02-15
+
+### Added
+
+- Second candidate feature.
+
+### Fixed
+
+- Final fix.
+- Candidate fix.
+
+## [1.2.0-rc.1] - 2023-02-01
+
+### Added
+
+- First candidate feature.
+
+## [1.1.0] - 2023-01-01
+
+### Added
+
+- Previous release.
+
+[Unreleased]: https://github.com/example/project/compare/v1.2.0...HEAD
+[1.2.0]: https://github.com/example/project/compare/v1.2.0-rc.2...v1.2.0
+[1.2.0-rc.2]: https://github.com/example/project/compare/v1.2.0-rc.1...v1.2.0-rc.2
+[1.2.0-rc.1]: https://github.com/example/project/compare/v1.1.0...v1.2.0-rc.1
+`), w, "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2023-03-01
+
+### Added
+
+- Second candidate feature.
+- First candidate feature.
+
+### Fixed
+
+- Final fix.
+- Candidate fix.
+
+## [1.1.0] - 2023-01-01
+
+### Added
+
+- Previous release.
+
+[Unreleased]: https://github.com/example/project/compare/v1.2.0...HEAD
+[1.2.0]: https://github.com/example/project/compare/v1.1.0...v1.2.0
+`, w.String())
+	assert.NoError(t, KeepAChangelog{}.Lint(strings.NewReader(w.String()), CheckRelease))
+
+	err = KeepAChangelog{}.RollUp(strings.NewReader(kacChangelog), &bytes.Buffer{}, "1.3.0")
+	assert.Error(t, err)
+}
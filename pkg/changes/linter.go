@@ -1,7 +1,6 @@
 package changes
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"regexp"
@@ -126,18 +125,24 @@ func (s *checkStatus) failf(
 	s.fail(lineNumber, fmt.Sprintf(f, args...))
 }
 
-// Check executes the changelog linter against the reader. It scans each line of
-// the changelog and checks it for problems. If there are no errors, this method
-// returns nil. If one or more problems are detected, they will be returned as
-// an Error.
+// Check executes the changelog linter against the reader. It parses the
+// changelog and checks each line for problems. If there are no errors, this
+// method returns nil. If one or more problems are detected, they will be
+// returned as an Error.
 func (l *Linter) Check() error {
-	status := checkStatus{}
+	doc, err := Parse(l.r)
+	if err != nil {
+		return err
+	}
+
+	return l.CheckDocument(doc)
+}
 
-	s := bufio.NewScanner(l.r)
-	n := 0
-	for s.Scan() {
-		n++
-		l.checkLine(n, s.Text(), &status)
+// CheckDocument performs the linter checks on an already parsed changelog.
+func (l *Linter) CheckDocument(doc *Document) error {
+	status := checkStatus{}
+	for _, line := range doc.Lines() {
+		l.checkLine(line, &status)
 	}
 
 	if len(status.Failures) > 0 {
@@ -151,17 +156,16 @@ var (
 	versionHeading      = regexp.MustCompile(`^v(\d\S+) {2}(20\d\d-\d\d-\d\d)$`) // "vstring  date" lines
 	logLineStart        = regexp.MustCompile(`^ \* (.*)$`)                       // bullet start lines
 	logLineContinuation = regexp.MustCompile(`^ {3}(.*)$`)                       // bullet continuation lines
-	blankLine           = regexp.MustCompile(`^$`)                               // completely empty lines
 	whitespaceLine      = regexp.MustCompile(`^\s+$`)                            // lines containing whitespace
 )
 
 // checkLine is the workhorse function that checks that each line makes sense
 // given the current status of the checks up to this point.
 func (l *Linter) checkLine(
-	lineNumber int,
-	line string,
+	docLine Line,
 	status *checkStatus,
 ) {
+	lineNumber, line := docLine.Number, docLine.Text
 	lineIsBlank := false
 	lineIsBullet := false
 
@@ -170,7 +174,7 @@ func (l *Linter) checkLine(
 		status.previousLineWasBullet = lineIsBullet
 	}()
 
-	if line == "WIP" || line == "WIP  TBD" {
+	if docLine.Kind == LineHeading && (line == "WIP" || line == WIPHeading) {
 		if lineNumber > 1 {
 			status.fail(lineNumber, "WIP found after line 1")
 		}
@@ -189,14 +193,15 @@ func (l *Linter) checkLine(
 		status.fail(lineNumber, "WIP not found during pre-release check")
 	}
 
-	if m := versionHeading.FindStringSubmatch(line); m != nil {
+	if m := versionHeading.FindStringSubmatch(line); docLine.Kind == LineHeading && m != nil {
 		ver, date := m[1], m[2]
-		version, err := semver.NewVersion(ver)
+		version, err := ParseVersion(ver)
 		if err != nil {
 			status.fail(lineNumber, "Unable to parse version number in heading")
 
 			// this is fatal for this line, checks cannot continue
 			status.previousLine = lineNumber
+			return
 		}
 
 		// version and date are in descending order in a changelog
@@ -222,7 +227,7 @@ func (l *Linter) checkLine(
 		return
 	}
 
-	if m := logLineStart.FindStringSubmatch(line); m != nil {
+	if docLine.Kind == LineBullet {
 		if status.previousLine == 0 {
 			status.fail(lineNumber, "log bullet before first version heading or WIP")
 		}
@@ -240,17 +245,13 @@ func (l *Linter) checkLine(
 		return
 	}
 
-	if m := logLineContinuation.FindStringSubmatch(line); m != nil {
-		if status.previousLineWasBullet {
-			lineIsBullet = true
-		} else {
-			status.fail(lineNumber, "log line continuation has not bullet to continue")
-		}
+	if docLine.Kind == LineContinuation {
+		lineIsBullet = true
 
 		return
 	}
 
-	if blankLine.MatchString(line) {
+	if docLine.Kind == LineBlank {
 		if status.previousLineWasBlank {
 			status.fail(lineNumber, "consecutive blank lines")
 		}
@@ -266,5 +267,11 @@ func (l *Linter) checkLine(
 		return
 	}
 
+	if logLineContinuation.MatchString(line) {
+		status.fail(lineNumber, "log line continuation has not bullet to continue")
+
+		return
+	}
+
 	status.fail(lineNumber, "badly formatted line: it must be blank, start with a space or bullet (\" * \"), or a heading of the form \"version  date\"")
 }
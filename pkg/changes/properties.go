@@ -0,0 +1,119 @@
+package changes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+const (
+	PropertyChangelogFile = "changelog.file"
+
+	// DefaultChangelog is the changelog file path to use when none is
+	// configured.
+	DefaultChangelog = "Changes.md"
+
+	PropertyChangelogFormat = "changelog.format"
+
+	// DefaultFormat is the name of the changelog format to use when none is
+	// configured.
+	DefaultFormat = "zedpm"
+
+	PropertyChangelogEntryText     = "changelog.entry.text"
+	PropertyChangelogEntryCategory = "changelog.entry.category"
+	PropertyChangelogEntryPR       = "changelog.entry.pr"
+
+	PropertyChangelogRollUp = "changelog.rollup"
+
+	PropertyChangelogFix = "changelog.fix"
+
+	PropertyChangelogCoverageRatio = "changelog.coverage.ratio"
+	PropertyChangelogCoveragePRs   = "changelog.coverage.prs"
+
+	// DefaultCoverageRatio is the minimum number of unreleased changes
+	// expected per commit when none is configured.
+	DefaultCoverageRatio = 0.1
+)
+
+// GetPropertyChangelogFile gets the name of the changelog file from the
+// configuration or returns the default value. A relative name is relative to
+// the directory of the module the goal is scoped to (see module.dir), so each
+// module has its own changelog.
+func GetPropertyChangelogFile(ctx context.Context) string {
+	name := DefaultChangelog
+	if plugin.IsSet(ctx, PropertyChangelogFile) {
+		name = plugin.GetString(ctx, PropertyChangelogFile)
+	}
+	return goals.ModulePath(ctx, name)
+}
+
+// GetPropertyChangelogFormat returns the changelog format named by the
+// changelog.format property or DefaultFormat if not set.
+func GetPropertyChangelogFormat(ctx context.Context) (Format, error) {
+	name := plugin.GetString(ctx, PropertyChangelogFormat)
+	if name == "" {
+		name = DefaultFormat
+	}
+	return GetFormat(name)
+}
+
+// GetPropertyChangelogEntry returns the changelog entry described by the
+// changelog.entry.text, changelog.entry.category, and changelog.entry.pr
+// properties. If changelog.entry.pr is set, the pull request number is appended
+// to the text. It returns nil if changelog.entry.text is not set.
+func GetPropertyChangelogEntry(ctx context.Context) (*Entry, error) {
+	text := strings.TrimSpace(plugin.GetString(ctx, PropertyChangelogEntryText))
+	if text == "" {
+		return nil, nil
+	}
+
+	var (
+		category string
+		err      error
+	)
+	if c := plugin.GetString(ctx, PropertyChangelogEntryCategory); c != "" {
+		category, err = ParseCategory(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if pr := strings.TrimPrefix(plugin.GetString(ctx, PropertyChangelogEntryPR), "#"); pr != "" {
+		text = fmt.Sprintf("%s (#%s)", text, pr)
+	}
+
+	return &Entry{Category: category, Text: text}, nil
+}
+
+// GetPropertyChangelogRollUp returns the value of changelog.rollup, which
+// determines whether the sections for pre-releases are rolled up into the
+// section for the final release.
+func GetPropertyChangelogRollUp(ctx context.Context) bool {
+	return plugin.GetBool(ctx, PropertyChangelogRollUp)
+}
+
+// GetPropertyChangelogFix returns the value of changelog.fix, which determines
+// whether the lint goal rewrites the changelog to repair the problems that can
+// be fixed automatically. It is false when not set.
+func GetPropertyChangelogFix(ctx context.Context) bool {
+	return plugin.GetBool(ctx, PropertyChangelogFix)
+}
+
+// GetPropertyChangelogCoverageRatio returns the value of
+// changelog.coverage.ratio or DefaultCoverageRatio if not set.
+func GetPropertyChangelogCoverageRatio(ctx context.Context) float64 {
+	if plugin.IsSet(ctx, PropertyChangelogCoverageRatio) {
+		return plugin.GetFloat64(ctx, PropertyChangelogCoverageRatio)
+	}
+	return DefaultCoverageRatio
+}
+
+// GetPropertyChangelogCoveragePRs returns the value of changelog.coverage.prs,
+// which determines whether every merged pull request must be mentioned in the
+// changelog. It is false when not set.
+func GetPropertyChangelogCoveragePRs(ctx context.Context) bool {
+	return plugin.GetBool(ctx, PropertyChangelogCoveragePRs)
+}
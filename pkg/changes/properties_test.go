@@ -0,0 +1,80 @@
+package changes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestGetPropertyChangelogEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		props    map[string]any
+		entry    *Entry
+		hasError bool
+	}{
+		{
+			name:  "missing text",
+			props: map[string]any{PropertyChangelogEntryCategory: "fixed", PropertyChangelogEntryPR: "12"},
+		},
+		{
+			name:  "blank text",
+			props: map[string]any{PropertyChangelogEntryText: "  "},
+		},
+		{
+			name:  "text only",
+			props: map[string]any{PropertyChangelogEntryText: " A change. "},
+			entry: &Entry{Text: "A change."},
+		},
+		{
+			name: "category",
+			props: map[string]any{
+				PropertyChangelogEntryText:     "A bug.",
+				PropertyChangelogEntryCategory: "FIXED",
+			},
+			entry: &Entry{Category: CategoryFixed, Text: "A bug."},
+		},
+		{
+			name: "unknown category",
+			props: map[string]any{
+				PropertyChangelogEntryText:     "A bug.",
+				PropertyChangelogEntryCategory: "fix",
+			},
+			hasError: true,
+		},
+		{
+			name:  "pull request",
+			props: map[string]any{PropertyChangelogEntryText: "A change.", PropertyChangelogEntryPR: "12"},
+			entry: &Entry{Text: "A change. (#12)"},
+		},
+		{
+			name:  "pull request with hash",
+			props: map[string]any{PropertyChangelogEntryText: "A change.", PropertyChangelogEntryPR: "#12"},
+			entry: &Entry{Text: "A change. (#12)"},
+		},
+	}
+
+	for _, test := range tests {
+		kv := storage.New()
+		for k, v := range test.props {
+			kv.Set(k, v)
+		}
+		ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+
+		entry, err := GetPropertyChangelogEntry(ctx)
+		if test.hasError {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.entry, entry, test.name)
+	}
+}
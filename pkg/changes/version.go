@@ -0,0 +1,45 @@
+package changes
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// ReadLatestVersion returns the version of the most recent release recorded in
+// the configured changelog, using the configured changelog format. It returns
+// nil if the changelog has no releases recorded.
+func ReadLatestVersion(ctx context.Context) (*semver.Version, error) {
+	format, err := GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := os.Open(GetPropertyChangelogFile(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return format.LatestVersion(r)
+}
+
+// ParseVersion parses the version named in a changelog heading. A leading "v"
+// is ignored and a version with only a major and minor number, such as "1.2",
+// is taken to have a patch number of 0.
+func ParseVersion(version string) (*semver.Version, error) {
+	version = strings.TrimPrefix(version, "v")
+
+	core, rest := version, ""
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		core, rest = version[:i], version[i:]
+	}
+
+	if strings.Count(core, ".") == 1 {
+		version = core + ".0" + rest
+	}
+
+	return semver.NewVersion(version)
+}
@@ -0,0 +1,132 @@
+package changes
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// Verify that Zedpm is a Format and a RollUpper.
+var (
+	_ Format    = Zedpm{}
+	_ RollUpper = Zedpm{}
+)
+
+// Zedpm implements the changelog format used by zedpm itself. See Linter for
+// a description of the format.
+type Zedpm struct{}
+
+// Lint checks the changelog using the Linter.
+func (Zedpm) Lint(r io.Reader, mode CheckMode) error {
+	return NewLinter(r, mode).Check()
+}
+
+// ExtractSection returns the bullets of the section for the given version.
+func (Zedpm) ExtractSection(r io.Reader, version string) (io.Reader, error) {
+	return extractSection(r, version)
+}
+
+// FixupForRelease replaces the WIP heading with a version heading.
+func (Zedpm) FixupForRelease(
+	r io.Reader,
+	w io.Writer,
+	version string,
+	date time.Time,
+) error {
+	doc, err := Parse(r)
+	if err != nil {
+		return err
+	}
+
+	wip := doc.WIP()
+	if wip == nil {
+		return errors.New("no WIP section found in changelog")
+	}
+
+	wip.SetRelease(version, date.Format("2006-01-02"))
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// zedpmCategoryPrefix maps categories to the prefix added to the bullet text
+// to identify the category. Bullets for additions and changes are not prefixed.
+var zedpmCategoryPrefix = map[string]string{
+	CategoryDeprecated: "Deprecated: ",
+	CategoryRemoved:    "Removed: ",
+	CategoryFixed:      "Fix: ",
+	CategorySecurity:   "Security: ",
+}
+
+// AddUnreleased adds a bullet for each entry to the WIP section. Entries in the
+// deprecated, removed, fixed, and security categories are prefixed with the
+// name of the category, e.g., "Fix: ".
+func (Zedpm) AddUnreleased(r io.Reader, w io.Writer, entries []Entry) error {
+	bullets := make([]string, len(entries))
+	for i, e := range entries {
+		bullets[i] = zedpmCategoryPrefix[e.Category] + e.Text
+	}
+	return InsertWIP(r, w, bullets)
+}
+
+// Unreleased returns the bullets of the WIP section. The category of each entry
+// is determined from the prefix of the bullet, if it has one.
+func (Zedpm) Unreleased(r io.Reader) ([]Entry, error) {
+	doc, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	wip := doc.WIP()
+	if wip == nil {
+		return nil, nil
+	}
+
+	bullets := wip.Bullets()
+	entries := make([]Entry, len(bullets))
+	for i, b := range bullets {
+		entries[i].Text = b.Text
+		for category, prefix := range zedpmCategoryPrefix {
+			if strings.HasPrefix(b.Text, prefix) {
+				entries[i].Category = category
+				break
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// LatestVersion returns the version of the first version heading.
+func (Zedpm) LatestVersion(r io.Reader) (*semver.Version, error) {
+	doc, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := doc.LatestRelease()
+	if s == nil {
+		return nil, nil
+	}
+
+	return ParseVersion(s.Version)
+}
+
+// RollUp rolls the sections for pre-releases of the given version into the
+// section for the version. See Document.RollUpPreReleases.
+func (Zedpm) RollUp(r io.Reader, w io.Writer, version string) error {
+	doc, err := Parse(r)
+	if err != nil {
+		return err
+	}
+
+	if _, err := doc.RollUpPreReleases(version); err != nil {
+		return err
+	}
+
+	_, err = doc.WriteTo(w)
+	return err
+}
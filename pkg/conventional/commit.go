@@ -0,0 +1,107 @@
+package conventional
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Commit is the parsed form of a conventional commit message.
+type Commit struct {
+	// Type is the type of commit, e.g., "feat" or "fix". It is always lower
+	// case.
+	Type string
+
+	// Scope is the optional scope given in parenthesis after the type.
+	Scope string
+
+	// Breaking is true if the commit was marked with a "!" after the type and
+	// scope or if the message contains a BREAKING CHANGE footer.
+	Breaking bool
+
+	// Description is the text of the subject line following the colon.
+	Description string
+
+	// Body is everything after the subject line, with surrounding whitespace
+	// removed.
+	Body string
+}
+
+const (
+	TypeFeature     = "feat"
+	TypeFix         = "fix"
+	TypePerformance = "perf"
+)
+
+var (
+	subjectLine    = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(\S.*)$`)
+	breakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// Parse parses the given commit message. It returns nil if the subject line of
+// the message is not a conventional commit subject.
+func Parse(message string) *Commit {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	m := subjectLine.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return nil
+	}
+
+	body = strings.TrimSpace(body)
+	return &Commit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Breaking:    m[3] == "!" || breakingFooter.MatchString(body),
+		Description: m[4],
+		Body:        body,
+	}
+}
+
+// IsFeature returns true for commits of type "feat".
+func (c *Commit) IsFeature() bool {
+	return c.Type == TypeFeature
+}
+
+// IsFix returns true for commits of type "fix".
+func (c *Commit) IsFix() bool {
+	return c.Type == TypeFix
+}
+
+// IsPerformance returns true for commits of type "perf".
+func (c *Commit) IsPerformance() bool {
+	return c.Type == TypePerformance
+}
+
+// exemptSubject matches the subject lines of commit messages that git
+// generates, which need not be conventional.
+var exemptSubject = regexp.MustCompile(`^(?:Merge |Revert "|fixup! |squash! |amend! )`)
+
+// CheckMessage returns an error if the given commit message, as written by git
+// to the file passed to the commit-msg hook, is not a conventional commit.
+// Comment lines starting with "#" are ignored, as are merges, reverts, and the
+// fixup and squash commits made by git commit --fixup and --squash.
+func CheckMessage(message string) error {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	message = strings.TrimSpace(strings.Join(lines, "\n"))
+	if message == "" {
+		return errors.New("commit message is empty")
+	}
+
+	if exemptSubject.MatchString(message) {
+		return nil
+	}
+
+	if Parse(message) == nil {
+		subject, _, _ := strings.Cut(message, "\n")
+		return fmt.Errorf("commit message subject %q is not of the form \"type(scope): description\"", subject)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckMessage("feat(git): add hooks\n\nMore detail.\n# Please enter the commit message\n"))
+	assert.NoError(t, CheckMessage("# leading comment\nfix: repair the frobnicator\n"))
+	assert.NoError(t, CheckMessage("Merge branch 'main' into feature\n"))
+	assert.NoError(t, CheckMessage("fixup! feat: add hooks\n"))
+	assert.EqualError(t, CheckMessage("# only comments\n\n"), "commit message is empty")
+	assert.EqualError(t, CheckMessage("Added hooks\n"),
+		`commit message subject "Added hooks" is not of the form "type(scope): description"`)
+}
@@ -0,0 +1,3 @@
+// Package conventional provides tools for parsing commit messages written
+// according to the Conventional Commits specification.
+package conventional
@@ -0,0 +1,99 @@
+// Package forgetest provides the test scaffolding shared by the plugins for git
+// forges, such as Github, GitLab, and Gitea: a git repository to release from,
+// an httptest stand-in for the forge API, and a plugin context to run tasks in.
+package forgetest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// InitRepo creates a git repository on the named branch with a single commit
+// with the given message and an origin remote with the given URL. It changes
+// into the repository for the rest of the test and returns its directory.
+func InitRepo(t *testing.T, remoteURL, branch, message string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run("init", "-q", "-b", branch)
+	run("config", "user.name", "Test")
+	run("config", "user.email", "test@example.com")
+	run("config", "commit.gpgSign", "false")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	run("add", "a.txt")
+	run("commit", "-q", "-m", message)
+	run("remote", "add", "origin", remoteURL)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	return dir
+}
+
+// NewServer starts the stand-in for the forge API, which is closed at the end
+// of the test, and returns its URL.
+func NewServer(t *testing.T, fake http.Handler) string {
+	t.Helper()
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// Context returns a plugin context with a null logger and the given
+// properties. Later property maps override earlier ones.
+func Context(props ...map[string]string) context.Context {
+	return LoggedContext(io.Discard, props...)
+}
+
+// LoggedContext is like Context, but writes the log to w.
+func LoggedContext(w io.Writer, props ...map[string]string) context.Context {
+	kv := storage.New()
+	for _, p := range props {
+		kv.UpdateStrings(p)
+	}
+
+	logger := log.New(hclog.New(&hclog.LoggerOptions{Output: w}))
+	return plugin.InitializeContext(context.Background(), plugin.NewContext(logger, kv))
+}
+
+// Decode reads the JSON object in the body of the request. The result is empty
+// if the body is not a JSON object.
+func Decode(r *http.Request) map[string]any {
+	body := map[string]any{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	return body
+}
+
+// Reply writes the body as JSON with the given status.
+func Reply(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
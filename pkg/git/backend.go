@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Backend is the interface through which zedpm modifies a git repository. Each
+// backend reports status and references using the go-git types, so that
+// callers do not depend on which backend is in use.
+type Backend interface {
+	// Head returns the reference HEAD points to. When HEAD is detached, the
+	// reference is named HEAD.
+	Head() (*plumbing.Reference, error)
+
+	// Status returns the status of the files in the working copy.
+	Status() (git.Status, error)
+
+	// Checkout switches the working copy to a branch, creating it first when
+	// requested.
+	Checkout(opts *CheckoutOptions) error
+
+	// Add stages the named file to be committed.
+	Add(path string) error
+
+	// Commit commits the staged changes with the given message and returns the
+	// hash of the new commit. The commit is signed unless sign is nil.
+	Commit(message string, sign *Signing) (plumbing.Hash, error)
+
+	// CreateTag creates an annotated tag with the given name and message on
+	// the target commit. The tag is signed unless sign is nil.
+	CreateTag(name string, target plumbing.Hash, message string, sign *Signing) error
+
+	// VerifyCommit returns an error unless the commit has a good signature
+	// made with the signing key.
+	VerifyCommit(hash plumbing.Hash, sign *Signing) error
+
+	// VerifyTag returns an error unless the named tag has a good signature
+	// made with the signing key.
+	VerifyTag(name string, sign *Signing) error
+
+	// Push pushes the given ref specs to the named remote. A ref spec with an
+	// empty source deletes the destination reference from the remote (see
+	// DeleteRefSpec).
+	Push(remote string, refSpecs ...gitConfig.RefSpec) error
+
+	// ListRemote returns the references found on the named remote.
+	ListRemote(remote string) ([]*plumbing.Reference, error)
+
+	// Remotes returns the remotes configured for the repository.
+	Remotes() ([]*Remote, error)
+
+	// DeleteRef deletes the named reference from the local repository.
+	DeleteRef(name plumbing.ReferenceName) error
+}
+
+// CheckoutOptions describes the branch to switch to with Backend.Checkout.
+type CheckoutOptions struct {
+	// Branch is the branch to checkout.
+	Branch plumbing.ReferenceName
+
+	// Hash is the commit to start a new branch from. If zero, the new branch
+	// starts from HEAD.
+	Hash plumbing.Hash
+
+	// Create is set to create the branch before switching to it.
+	Create bool
+}
+
+// Remote describes a configured remote.
+type Remote struct {
+	// Name is the name of the remote, e.g., "origin".
+	Name string
+
+	// URLs are the URLs configured for the remote.
+	URLs []string
+}
+
+// BackendOpener opens the git repository in the named directory.
+type BackendOpener func(dir string) (Backend, error)
+
+// Backends lists the available git backends by name.
+var Backends = map[string]BackendOpener{
+	"go-git": OpenGoGit,
+	"cli":    OpenCLI,
+}
+
+// OpenBackend opens the repository in the named directory using the named
+// backend.
+func OpenBackend(name, dir string) (Backend, error) {
+	open, ok := Backends[name]
+	if !ok {
+		names := make([]string, 0, len(Backends))
+		for n := range Backends {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown git backend %q (expected one of: %s)", name, strings.Join(names, ", "))
+	}
+
+	return open(dir)
+}
+
+// DeleteRefSpec returns a ref spec that deletes the named reference from the
+// remote when pushed.
+func DeleteRefSpec(name plumbing.ReferenceName) gitConfig.RefSpec {
+	return gitConfig.RefSpec(":" + string(name))
+}
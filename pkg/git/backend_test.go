@@ -0,0 +1,141 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initRepo creates a repository with a single commit on master and a bare
+// origin remote, returning the path to each.
+func initRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command is not installed")
+	}
+
+	dir := t.TempDir()
+	origin := t.TempDir()
+	run := func(dir string, args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run(origin, "init", "-q", "--bare")
+	run(dir, "init", "-q", "-b", "master")
+	run(dir, "config", "user.name", "Test User")
+	run(dir, "config", "user.email", "test@example.com")
+	run(dir, "config", "commit.gpgsign", "false")
+	run(dir, "config", "tag.gpgsign", "false")
+	run(dir, "remote", "add", "origin", origin)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	run(dir, "add", "a.txt")
+	run(dir, "commit", "-q", "-m", "first")
+
+	return dir, origin
+}
+
+func TestBackends(t *testing.T) {
+	t.Parallel()
+
+	for name := range Backends {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, origin := initRepo(t)
+
+			b, err := OpenBackend(name, dir)
+			require.NoError(t, err)
+
+			head, err := b.Head()
+			require.NoError(t, err)
+			assert.Equal(t, plumbing.NewBranchReferenceName("master"), head.Name())
+
+			remotes, err := b.Remotes()
+			require.NoError(t, err)
+			assert.Equal(t, []*Remote{{Name: "origin", URLs: []string{origin}}}, remotes)
+
+			branch := plumbing.NewBranchReferenceName("release-v1.0.0")
+			require.NoError(t, b.Checkout(&CheckoutOptions{
+				Branch: branch,
+				Hash:   head.Hash(),
+				Create: true,
+			}))
+
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("b\n"), 0o644))
+			stat, err := b.Status()
+			require.NoError(t, err)
+			assert.Equal(t, git.Modified, stat.File("a.txt").Worktree)
+
+			require.NoError(t, b.Add("a.txt"))
+			hash, err := b.Commit("second", nil)
+			require.NoError(t, err)
+
+			head, err = b.Head()
+			require.NoError(t, err)
+			assert.Equal(t, branch, head.Name())
+			assert.Equal(t, hash, head.Hash())
+
+			require.NoError(t, b.CreateTag("v1.0.0", hash, "Release tag", nil))
+
+			branchSpec := gitConfig.RefSpec(branch + ":" + branch)
+			tagSpec := gitConfig.RefSpec("refs/tags/v1.0.0:refs/tags/v1.0.0")
+			require.NoError(t, b.Push("origin", branchSpec, tagSpec))
+
+			refs, err := b.ListRemote("origin")
+			require.NoError(t, err)
+			names := map[plumbing.ReferenceName]plumbing.Hash{}
+			for _, ref := range refs {
+				names[ref.Name()] = ref.Hash()
+			}
+			assert.Equal(t, hash, names[branch])
+			assert.Contains(t, names, plumbing.NewTagReferenceName("v1.0.0"))
+
+			require.NoError(t, b.Push("origin", DeleteRefSpec(branch)))
+			refs, err = b.ListRemote("origin")
+			require.NoError(t, err)
+			for _, ref := range refs {
+				assert.NotEqual(t, branch, ref.Name())
+			}
+
+			require.NoError(t, b.Checkout(&CheckoutOptions{
+				Branch: plumbing.NewBranchReferenceName("master"),
+			}))
+			require.NoError(t, b.DeleteRef(branch))
+			require.NoError(t, b.DeleteRef(plumbing.NewTagReferenceName("v1.0.0")))
+
+			repo, err := git.PlainOpen(dir)
+			require.NoError(t, err)
+			_, err = repo.Reference(branch, false)
+			assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+		})
+	}
+
+	_, err := OpenBackend("svn", ".")
+	assert.EqualError(t, err, `unknown git backend "svn" (expected one of: cli, go-git)`)
+}
+
+func TestParsePorcelainStatus(t *testing.T) {
+	t.Parallel()
+
+	stat, err := ParsePorcelainStatus(" M a.txt\x00R  new.txt\x00old.txt\x00?? b.txt\x00")
+	require.NoError(t, err)
+	assert.Equal(t, git.Status{
+		"a.txt":   {Staging: git.Unmodified, Worktree: git.Modified},
+		"new.txt": {Staging: git.Renamed, Worktree: git.Unmodified, Extra: "old.txt"},
+		"b.txt":   {Staging: git.Untracked, Worktree: git.Untracked},
+	}, stat)
+
+	_, err = ParsePorcelainStatus("bogus")
+	assert.Error(t, err)
+}
@@ -0,0 +1,313 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// CLI is the Backend implemented by running the git command. Unlike GoGit, it
+// honors the complete git configuration of the user, including credential
+// helpers, SSH agents, includeIf, and commit signing.
+type CLI struct {
+	dir string
+}
+
+// Verify that CLI implements Backend.
+var _ Backend = &CLI{}
+
+// OpenCLI checks that the named directory is a git repository and returns a
+// backend that runs the git command in that directory.
+func OpenCLI(dir string) (Backend, error) {
+	g := &CLI{dir}
+	if _, err := g.git("rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("unable to open git repository at %s: %w", dir, err)
+	}
+	return g, nil
+}
+
+// git runs the git command with the given arguments and returns its standard
+// output. If the command fails, the error includes its standard error.
+func (g *CLI) git(args ...string) (string, error) {
+	return g.gitWith(nil, args...)
+}
+
+// gitWith runs the git command like git, but also passes the given
+// configuration settings with -c.
+func (g *CLI) gitWith(config []string, args ...string) (string, error) {
+	cmdArgs := []string{"-C", g.dir}
+	for _, c := range config {
+		cmdArgs = append(cmdArgs, "-c", c)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.Command("git", cmdArgs...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+
+	return stdout.String(), nil
+}
+
+// revParse resolves the given revision to a hash.
+func (g *CLI) revParse(rev string) (plumbing.Hash, error) {
+	out, err := g.git("rev-parse", "--verify", rev)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return plumbing.NewHash(strings.TrimSpace(out)), nil
+}
+
+// Head returns the HEAD reference.
+func (g *CLI) Head() (*plumbing.Reference, error) {
+	hash, err := g.revParse("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// symbolic-ref fails when HEAD is detached
+	name := plumbing.HEAD
+	if out, err := g.git("symbolic-ref", "-q", "HEAD"); err == nil {
+		name = plumbing.ReferenceName(strings.TrimSpace(out))
+	}
+
+	return plumbing.NewHashReference(name, hash), nil
+}
+
+// Status returns the status of the working copy, parsed from git status
+// --porcelain, whose status codes are the same as those used by go-git.
+func (g *CLI) Status() (git.Status, error) {
+	out, err := g.git("status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePorcelainStatus(out)
+}
+
+// ParsePorcelainStatus parses the output of git status --porcelain=v1 -z.
+func ParsePorcelainStatus(out string) (git.Status, error) {
+	status := git.Status{}
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		if len(entry) < 4 || entry[2] != ' ' {
+			return nil, fmt.Errorf("unable to parse git status entry %q", entry)
+		}
+
+		fs := &git.FileStatus{
+			Staging:  git.StatusCode(entry[0]),
+			Worktree: git.StatusCode(entry[1]),
+		}
+
+		// renames and copies are followed by the original name
+		if fs.Staging == git.Renamed || fs.Staging == git.Copied {
+			i++
+			if i < len(entries) {
+				fs.Extra = entries[i]
+			}
+		}
+
+		status[entry[3:]] = fs
+	}
+
+	return status, nil
+}
+
+// Checkout switches to the given branch.
+func (g *CLI) Checkout(opts *CheckoutOptions) error {
+	branch := opts.Branch.Short()
+	if !opts.Create {
+		_, err := g.git("checkout", branch)
+		return err
+	}
+
+	args := []string{"checkout", "-b", branch}
+	if !opts.Hash.IsZero() {
+		args = append(args, opts.Hash.String())
+	}
+
+	_, err := g.git(args...)
+	return err
+}
+
+// Add stages the named file.
+func (g *CLI) Add(path string) error {
+	_, err := g.git("add", "--", path)
+	return err
+}
+
+// signConfig returns the git configuration settings used to sign with the
+// given key.
+func signConfig(sign *Signing) []string {
+	config := []string{"gpg.format=" + sign.Format}
+	if sign.Key != "" {
+		config = append(config, "user.signingKey="+sign.Key)
+	}
+	return config
+}
+
+// Commit commits the staged changes. If sign is nil, the commit is made
+// without a signature, regardless of the commit.gpgSign setting.
+func (g *CLI) Commit(message string, sign *Signing) (plumbing.Hash, error) {
+	var err error
+	if sign == nil {
+		_, err = g.git("commit", "--no-gpg-sign", "-m", message)
+	} else {
+		_, err = g.gitWith(signConfig(sign), "commit", "--gpg-sign", "-m", message)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return g.revParse("HEAD")
+}
+
+// CreateTag creates an annotated tag, which is signed unless sign is nil.
+func (g *CLI) CreateTag(name string, target plumbing.Hash, message string, sign *Signing) error {
+	var err error
+	if sign == nil {
+		_, err = g.gitWith([]string{"tag.gpgSign=false"}, "tag", "-a", "-m", message, name, target.String())
+	} else {
+		_, err = g.gitWith(signConfig(sign), "tag", "-s", "-m", message, name, target.String())
+	}
+	return err
+}
+
+// verify runs the given verify-commit or verify-tag command. For SSH
+// signatures made with a configured key, the key is trusted through a
+// temporary allowed signers file. Otherwise, the signature must be trusted by
+// the git configuration.
+func (g *CLI) verify(sign *Signing, args ...string) error {
+	if sign == nil {
+		return fmt.Errorf("no signing key is configured")
+	}
+
+	config := signConfig(sign)
+	if sign.Format == SignFormatSSH && sign.Key != "" {
+		pub, err := sign.sshPublicKey()
+		if err != nil {
+			return err
+		}
+
+		f, err := os.CreateTemp("", "zedpm-allowed-signers-")
+		if err != nil {
+			return format.WrapErr(err, "unable to create allowed signers file")
+		}
+		defer func() { _ = os.Remove(f.Name()) }()
+
+		_, err = fmt.Fprintf(f, "* %s\n", pub)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return format.WrapErr(err, "unable to write allowed signers file")
+		}
+
+		config = append(config, "gpg.ssh.allowedSignersFile="+f.Name())
+	}
+
+	_, err := g.gitWith(config, args...)
+	return err
+}
+
+// VerifyCommit checks the signature of the commit with git verify-commit.
+func (g *CLI) VerifyCommit(hash plumbing.Hash, sign *Signing) error {
+	if err := g.verify(sign, "verify-commit", hash.String()); err != nil {
+		return format.WrapErr(err, "bad signature on commit %s", hash)
+	}
+	return nil
+}
+
+// VerifyTag checks the signature of the tag with git verify-tag.
+func (g *CLI) VerifyTag(name string, sign *Signing) error {
+	if err := g.verify(sign, "verify-tag", name); err != nil {
+		return format.WrapErr(err, "bad signature on tag %q", name)
+	}
+	return nil
+}
+
+// Push pushes the ref specs to the remote.
+func (g *CLI) Push(remote string, refSpecs ...gitConfig.RefSpec) error {
+	args := []string{"push", remote}
+	for _, rs := range refSpecs {
+		args = append(args, rs.String())
+	}
+
+	_, err := g.git(args...)
+	return err
+}
+
+// ListRemote lists the references on the remote, parsed from git ls-remote.
+func (g *CLI) ListRemote(remote string) ([]*plumbing.Reference, error) {
+	out, err := g.git("ls-remote", remote)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []*plumbing.Reference{}
+	for _, line := range strings.Split(out, "\n") {
+		hash, name, found := strings.Cut(line, "\t")
+		if !found || strings.HasSuffix(name, "^{}") {
+			continue
+		}
+
+		refs = append(refs, plumbing.NewHashReference(
+			plumbing.ReferenceName(name),
+			plumbing.NewHash(hash),
+		))
+	}
+
+	return refs, nil
+}
+
+// Remotes returns the configured remotes.
+func (g *CLI) Remotes() ([]*Remote, error) {
+	out, err := g.git("remote")
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := []*Remote{}
+	for _, name := range strings.Fields(out) {
+		urls, err := g.git("remote", "get-url", "--all", name)
+		if err != nil {
+			return nil, err
+		}
+
+		remotes = append(remotes, &Remote{
+			Name: name,
+			URLs: strings.Fields(urls),
+		})
+	}
+
+	return remotes, nil
+}
+
+// DeleteRef deletes the local reference.
+func (g *CLI) DeleteRef(name plumbing.ReferenceName) error {
+	_, err := g.git("update-ref", "-d", string(name))
+	return err
+}
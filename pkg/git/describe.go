@@ -0,0 +1,31 @@
+package git
+
+import "fmt"
+
+// Describe returns a description of HEAD in the style of git describe, which
+// names the last release tag, the number of commits since that tag, and the
+// abbreviated hash of HEAD, e.g., "v1.2.3-4-gabc1234-dirty". If HEAD is tagged,
+// only the tag is used. If there is no release tag, only the abbreviated hash
+// is used.
+func Describe(tag string, commitsSinceTag int, head string, dirty bool) string {
+	short := head
+	if len(short) > 7 {
+		short = short[:7]
+	}
+
+	var describe string
+	switch {
+	case tag == "":
+		describe = short
+	case commitsSinceTag == 0:
+		describe = tag
+	default:
+		describe = fmt.Sprintf("%s-%d-g%s", tag, commitsSinceTag, short)
+	}
+
+	if dirty {
+		describe += "-dirty"
+	}
+
+	return describe
+}
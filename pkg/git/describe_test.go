@@ -0,0 +1,18 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	const head = "abc1234def5678"
+	assert.Equal(t, "v1.2.3-4-gabc1234", Describe("v1.2.3", 4, head, false))
+	assert.Equal(t, "v1.2.3-4-gabc1234-dirty", Describe("v1.2.3", 4, head, true))
+	assert.Equal(t, "v1.2.3", Describe("v1.2.3", 0, head, false))
+	assert.Equal(t, "v1.2.3-dirty", Describe("v1.2.3", 0, head, true))
+	assert.Equal(t, "abc1234", Describe("", 12, head, false))
+}
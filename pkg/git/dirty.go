@@ -0,0 +1,152 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// splitPath splits a slash-separated path into the parts used for matching
+// gitignore patterns.
+func splitPath(path string) []string {
+	return strings.Split(filepath.ToSlash(path), "/")
+}
+
+// FilterDirty returns the names of the files in the status that make the
+// working copy dirty, sorted by name. A file is not dirty if it is unmodified,
+// if it is untracked and matches one of the excludes patterns, or if it
+// matches one of the ignore patterns.
+func FilterDirty(status git.Status, excludes, ignore []gitignore.Pattern) []string {
+	excluded := gitignore.NewMatcher(excludes)
+	ignored := gitignore.NewMatcher(ignore)
+
+	dirty := []string{}
+	for fn, fstat := range status {
+		if fstat.Worktree == git.Unmodified && fstat.Staging == git.Unmodified {
+			continue
+		}
+
+		path := splitPath(fn)
+		if ignored.Match(path, false) {
+			continue
+		}
+
+		if fstat.Worktree == git.Untracked && excluded.Match(path, false) {
+			continue
+		}
+
+		dirty = append(dirty, fn)
+	}
+
+	sort.Strings(dirty)
+	return dirty
+}
+
+// readExcludesFile reads the patterns from the named excludes file. It returns
+// no patterns if the file does not exist.
+func readExcludesFile(name string) ([]gitignore.Pattern, error) {
+	if strings.HasPrefix(name, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Join(home, name[2:])
+	}
+
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ps := []gitignore.Pattern{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" && !strings.HasPrefix(line, "#") {
+			ps = append(ps, gitignore.ParsePattern(line, nil))
+		}
+	}
+
+	return ps, nil
+}
+
+// ExcludePatterns returns the patterns git uses to ignore untracked files:
+// those in the .gitignore files of the work tree, .git/info/exclude, and the
+// file named by core.excludesFile in the repository, global, or system git
+// configuration. If no excludes file is configured, the default of
+// $XDG_CONFIG_HOME/git/ignore is used.
+func (g *Git) ExcludePatterns() ([]gitignore.Pattern, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to examine the working copy")
+	}
+
+	ps, err := gitignore.ReadPatterns(wt.Filesystem, nil)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read .gitignore files")
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read git configuration")
+	}
+
+	var excludes []gitignore.Pattern
+	if name := cfg.Raw.Section("core").Option("excludesfile"); name != "" {
+		excludes, err = readExcludesFile(name)
+	} else {
+		root := osfs.New("/")
+		excludes, err = gitignore.LoadGlobalPatterns(root)
+		if err == nil && excludes == nil {
+			excludes, err = gitignore.LoadSystemPatterns(root)
+		}
+		if err == nil && excludes == nil {
+			excludes, err = readExcludesFile(defaultExcludesFile())
+		}
+	}
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read core.excludesFile")
+	}
+
+	// patterns read later take priority, so the work tree comes last
+	return append(excludes, ps...), nil
+}
+
+// defaultExcludesFile returns the excludes file git uses when
+// core.excludesFile is not set.
+func defaultExcludesFile() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	return "~/.config/git/ignore"
+}
+
+// DirtyFiles returns the names of the files that make the working copy dirty.
+// Untracked files ignored by git are not dirty, nor are any files matching the
+// patterns listed in git.dirty.ignore.
+func (g *Git) DirtyFiles(ctx context.Context) ([]string, error) {
+	status, err := g.backend.Status()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to check working copy status")
+	}
+
+	excludes, err := g.ExcludePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	ignore := []gitignore.Pattern{}
+	for _, p := range GetPropertyGitDirtyIgnore(ctx) {
+		ignore = append(ignore, gitignore.ParsePattern(p, nil))
+	}
+
+	return FilterDirty(status, excludes, ignore), nil
+}
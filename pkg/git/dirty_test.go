@@ -0,0 +1,85 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterDirty(t *testing.T) {
+	t.Parallel()
+
+	status := git.Status{
+		"clean.go":      {Staging: git.Unmodified, Worktree: git.Unmodified},
+		"changed.go":    {Staging: git.Unmodified, Worktree: git.Modified},
+		"staged.go":     {Staging: git.Added, Worktree: git.Unmodified},
+		"new.go":        {Staging: git.Untracked, Worktree: git.Untracked},
+		".session.vim":  {Staging: git.Untracked, Worktree: git.Untracked},
+		"tracked.vim":   {Staging: git.Unmodified, Worktree: git.Modified},
+		"dist/app":      {Staging: git.Untracked, Worktree: git.Untracked},
+		"docs/notes.md": {Staging: git.Unmodified, Worktree: git.Modified},
+	}
+
+	excludes := []gitignore.Pattern{
+		gitignore.ParsePattern("*.vim", nil),
+		gitignore.ParsePattern("dist/", nil),
+	}
+	ignore := []gitignore.Pattern{
+		gitignore.ParsePattern("docs/", nil),
+	}
+
+	// excludes only apply to untracked files, as in git
+	assert.Equal(t,
+		[]string{"changed.go", "new.go", "staged.go", "tracked.vim"},
+		FilterDirty(status, excludes, ignore))
+}
+
+func TestDirtyFiles(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := initRepo(t)
+	write := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	excludesFile := filepath.Join(t.TempDir(), "ignore")
+	out, err := exec.Command("git", "-C", dir, "config", "core.excludesFile", excludesFile).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	write(".gitignore", "*.log\n")
+	write(".git/info/exclude", "scratch/\n")
+	require.NoError(t, os.WriteFile(excludesFile, []byte(".session.vim\n"), 0o644))
+	write("debug.log", "x")
+	write("scratch/notes", "x")
+	write(".session.vim", "x")
+	write("notes.tmp", "x")
+	write("a.txt", "changed\n")
+
+	for name := range Backends {
+		b, err := OpenBackend(name, dir)
+		require.NoError(t, err)
+
+		repo, err := git.PlainOpen(dir)
+		require.NoError(t, err)
+
+		g := &Git{backend: b, repo: repo}
+
+		dirty, err := g.DirtyFiles(propertyContext(map[string]string{}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{".gitignore", "a.txt", "notes.tmp"}, dirty, name)
+
+		dirty, err = g.DirtyFiles(propertyContext(map[string]string{
+			PropertyGitDirtyIgnore: "*.tmp, .gitignore",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.txt"}, dirty, name)
+	}
+}
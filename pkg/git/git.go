@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"strings"
 
@@ -10,24 +11,20 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 
 	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/plugin"
 )
 
-// TODO IgnoreStatus should probably not be a thing. We can just use .gitignore.
-
-// IgnoreStatus defines some global files to always ignore when checking for
-// dirtiness.
-var IgnoreStatus = map[string]struct{}{
-	".session.vim": {},
-}
-
-// Git provides tools for working with a Git repository. It sets up client
-// objects to work with the local repository, the remote repository, and the
-// local work tree.
+// Git provides tools for working with a Git repository. Changes to the
+// repository are made through the Backend selected by the git.backend
+// property. History is always read with go-git.
 type Git struct {
-	repo   *git.Repository
-	remote *git.Remote
-	wc     *git.Worktree
+	backend     Backend
+	repo        *git.Repository
+	remote      *Remote
+	pushRemotes []string
+	signing     *Signing
+	moduleDir   string
 }
 
 func ref(t, n string) plumbing.ReferenceName {
@@ -39,11 +36,10 @@ func refSpec(r plumbing.ReferenceName) gitConfig.RefSpec {
 	return gitConfig.RefSpec(strings.Join([]string{sr, sr}, ":"))
 }
 
+// TargetBranch returns the name of the branch releases are made from. It is
+// the same as GetPropertyGitTargetBranch.
 func TargetBranch(ctx context.Context) string {
-	if plugin.IsSet(ctx, "target_branch") {
-		return plugin.GetString(ctx, "target_branch")
-	}
-	return "master"
+	return GetPropertyGitTargetBranch(ctx)
 }
 
 func TargetBranchRefName(ctx context.Context) plumbing.ReferenceName {
@@ -75,7 +71,19 @@ func ReleaseTagRefSpec(ctx context.Context) (gitConfig.RefSpec, error) {
 	return refSpec(tagRefName), nil
 }
 
-func (g *Git) SetupGitRepo(ctx context.Context) error {
+// SetupGitHistory opens the git repository in the current directory with the
+// configured backend for reading its history and state. Unlike SetupGitRepo,
+// the remotes and signing settings are not loaded, so the repository does not
+// need to have any remotes.
+func (g *Git) SetupGitHistory(ctx context.Context) error {
+	b, err := OpenBackend(GetPropertyGitBackend(ctx), ".")
+	if err != nil {
+		return err
+	}
+
+	g.backend = b
+	g.moduleDir = goals.GetPropertyModuleDir(ctx)
+
 	l, err := git.PlainOpen(".")
 	if err != nil {
 		return format.WrapErr(err, "unable to open git repository at .")
@@ -83,31 +91,96 @@ func (g *Git) SetupGitRepo(ctx context.Context) error {
 
 	g.repo = l
 
-	r, err := g.repo.Remote("origin")
+	return nil
+}
+
+// SetupGitRepo opens the git repository in the current directory with the
+// configured backend, finds the configured remotes, and loads the signing
+// settings.
+func (g *Git) SetupGitRepo(ctx context.Context) error {
+	err := g.SetupGitHistory(ctx)
 	if err != nil {
-		return format.WrapErr(err, "unable to connect to remote origin")
+		return err
 	}
 
-	g.remote = r
+	g.signing, err = GetPropertyGitSigning(ctx)
+	if err != nil {
+		return err
+	}
 
-	w, err := g.repo.Worktree()
+	remotes, err := g.backend.Remotes()
 	if err != nil {
-		return format.WrapErr(err, "unable to examine the working copy")
+		return format.WrapErr(err, "unable to list git remotes")
 	}
 
-	g.wc = w
+	byName := make(map[string]*Remote, len(remotes))
+	for _, r := range remotes {
+		byName[r.Name] = r
+	}
+
+	remote := GetPropertyGitRemote(ctx)
+	g.remote = byName[remote]
+	if g.remote == nil {
+		return fmt.Errorf("unable to connect to remote %s: remote not found", remote)
+	}
+
+	g.pushRemotes = GetPropertyGitPushRemotes(ctx)
+	for _, name := range g.pushRemotes {
+		if byName[name] == nil {
+			return fmt.Errorf("unable to push to remote %s: remote not found", name)
+		}
+	}
 
 	return nil
 }
 
+// Backend returns the backend used to modify the repository.
+func (g *Git) Backend() Backend {
+	return g.backend
+}
+
+// Repository returns the go-git repository used to read history.
 func (g *Git) Repository() *git.Repository {
 	return g.repo
 }
 
-func (g *Git) Remote() *git.Remote {
+// Signing returns the settings used to sign release commits and tags or nil if
+// they are not signed.
+func (g *Git) Signing() *Signing {
+	return g.signing
+}
+
+// Remote returns the remote named by git.remote, against which the release is
+// checked.
+func (g *Git) Remote() *Remote {
 	return g.remote
 }
 
-func (g *Git) Worktree() *git.Worktree {
-	return g.wc
+// PushRemotes returns the names of the remotes the release is pushed to.
+func (g *Git) PushRemotes() []string {
+	return g.pushRemotes
+}
+
+// PushToRemotes pushes the ref spec to every one of the PushRemotes. For each
+// successful push, a cleanup task is added to delete the pushed reference from
+// that remote again.
+func (g *Git) PushToRemotes(ctx context.Context, refSpec gitConfig.RefSpec) error {
+	for _, remote := range g.pushRemotes {
+		remote := remote
+		err := g.backend.Push(remote, refSpec)
+		if err != nil {
+			return format.WrapErr(err, "unable to push %q to remote %s", refSpec.String(), remote)
+		}
+
+		plugin.ForCleanup(ctx, func() {
+			_ = g.backend.Push(remote, DeleteRefSpec(refSpec.Dst("")))
+		})
+
+		plugin.Logger(ctx,
+			"refSpec", refSpec,
+			"remote", remote,
+		).Info("Pushed to remote repository")
+	}
+
+	return nil
 }
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupGitHistory(t *testing.T) {
+	dir, _ := initRepo(t)
+	for _, args := range [][]string{
+		{"remote", "remove", "origin"},
+		{"tag", "v1.0.0"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	ctx := propertyContext(map[string]string{})
+
+	g := &Git{}
+	assert.Error(t, g.SetupGitRepo(ctx))
+
+	g = &Git{}
+	require.NoError(t, g.SetupGitHistory(ctx))
+	assert.Nil(t, g.Remote())
+
+	tag, err := g.LatestReleaseTag("v")
+	require.NoError(t, err)
+	require.NotNil(t, tag)
+	assert.Equal(t, "v1.0.0", tag.Name)
+}
@@ -0,0 +1,229 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// GoGit is the Backend implemented with go-git, which does not require the git
+// command to be installed.
+type GoGit struct {
+	repo *git.Repository
+}
+
+// Verify that GoGit implements Backend.
+var _ Backend = &GoGit{}
+
+// OpenGoGit opens the repository in the named directory with go-git.
+func OpenGoGit(dir string) (Backend, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to open git repository at %s", dir)
+	}
+
+	return &GoGit{repo}, nil
+}
+
+// Head returns the HEAD reference.
+func (g *GoGit) Head() (*plumbing.Reference, error) {
+	return g.repo.Head()
+}
+
+// worktree returns the go-git work tree.
+func (g *GoGit) worktree() (*git.Worktree, error) {
+	wc, err := g.repo.Worktree()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to examine the working copy")
+	}
+	return wc, nil
+}
+
+// Status returns the status of the working copy.
+func (g *GoGit) Status() (git.Status, error) {
+	wc, err := g.worktree()
+	if err != nil {
+		return nil, err
+	}
+	return wc.Status()
+}
+
+// Checkout switches to the given branch.
+func (g *GoGit) Checkout(opts *CheckoutOptions) error {
+	wc, err := g.worktree()
+	if err != nil {
+		return err
+	}
+
+	return wc.Checkout(&git.CheckoutOptions{
+		Hash:   opts.Hash,
+		Branch: opts.Branch,
+		Create: opts.Create,
+	})
+}
+
+// Add stages the named file.
+func (g *GoGit) Add(path string) error {
+	wc, err := g.worktree()
+	if err != nil {
+		return err
+	}
+
+	_, err = wc.Add(path)
+	return err
+}
+
+// signKey loads the OpenPGP key to sign with, or returns nil if sign is nil.
+// Only OpenPGP signing is supported by go-git.
+func (g *GoGit) signKey(sign *Signing) (*openpgp.Entity, error) {
+	if sign == nil {
+		return nil, nil
+	}
+
+	if sign.Format != SignFormatOpenPGP {
+		return nil, fmt.Errorf("the go-git backend does not support %s signing, use the cli backend instead", sign.Format)
+	}
+
+	return sign.OpenPGPEntity()
+}
+
+// Commit commits the staged changes.
+func (g *GoGit) Commit(message string, sign *Signing) (plumbing.Hash, error) {
+	key, err := g.signKey(sign)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	wc, err := g.worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return wc.Commit(message, &git.CommitOptions{
+		SignKey: key,
+	})
+}
+
+// CreateTag creates an annotated tag.
+func (g *GoGit) CreateTag(name string, target plumbing.Hash, message string, sign *Signing) error {
+	key, err := g.signKey(sign)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.repo.CreateTag(name, target, &git.CreateTagOptions{
+		Message: message,
+		SignKey: key,
+	})
+	return err
+}
+
+// verifyKey returns the armored public key of the OpenPGP signing key.
+func (g *GoGit) verifyKey(sign *Signing) (string, error) {
+	if sign == nil {
+		return "", fmt.Errorf("no signing key is configured")
+	}
+
+	key, err := g.signKey(sign)
+	if err != nil {
+		return "", err
+	}
+
+	return armoredPublicKey(key)
+}
+
+// VerifyCommit checks the signature of the commit.
+func (g *GoGit) VerifyCommit(hash plumbing.Hash, sign *Signing) error {
+	pub, err := g.verifyKey(sign)
+	if err != nil {
+		return err
+	}
+
+	c, err := g.repo.CommitObject(hash)
+	if err != nil {
+		return format.WrapErr(err, "unable to read commit %s", hash)
+	}
+
+	if _, err := c.Verify(pub); err != nil {
+		return format.WrapErr(err, "bad signature on commit %s", hash)
+	}
+
+	return nil
+}
+
+// VerifyTag checks the signature of the tag.
+func (g *GoGit) VerifyTag(name string, sign *Signing) error {
+	pub, err := g.verifyKey(sign)
+	if err != nil {
+		return err
+	}
+
+	ref, err := g.repo.Tag(name)
+	if err != nil {
+		return format.WrapErr(err, "unable to find tag %q", name)
+	}
+
+	t, err := g.repo.TagObject(ref.Hash())
+	if err != nil {
+		return format.WrapErr(err, "unable to read tag %q", name)
+	}
+
+	if _, err := t.Verify(pub); err != nil {
+		return format.WrapErr(err, "bad signature on tag %q", name)
+	}
+
+	return nil
+}
+
+// Push pushes the ref specs to the remote. It is not an error if the remote is
+// already up-to-date.
+func (g *GoGit) Push(remote string, refSpecs ...gitConfig.RefSpec) error {
+	err := g.repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   refSpecs,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// ListRemote lists the references on the remote.
+func (g *GoGit) ListRemote(remote string) ([]*plumbing.Reference, error) {
+	r, err := g.repo.Remote(remote)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to connect to remote %s", remote)
+	}
+
+	return r.List(&git.ListOptions{})
+}
+
+// Remotes returns the configured remotes.
+func (g *GoGit) Remotes() ([]*Remote, error) {
+	rs, err := g.repo.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]*Remote, len(rs))
+	for i, r := range rs {
+		cfg := r.Config()
+		remotes[i] = &Remote{
+			Name: cfg.Name,
+			URLs: cfg.URLs,
+		}
+	}
+
+	return remotes, nil
+}
+
+// DeleteRef deletes the local reference.
+func (g *GoGit) DeleteRef(name plumbing.ReferenceName) error {
+	return g.repo.Storer.RemoveReference(name)
+}
@@ -0,0 +1,272 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// ReleaseTag describes a tag marking a release.
+type ReleaseTag struct {
+	// Name is the name of the tag, e.g., "v1.2.3".
+	Name string
+
+	// Version is the version parsed from the tag name.
+	Version *semver.Version
+
+	// Commit is the hash of the commit that was tagged.
+	Commit plumbing.Hash
+}
+
+// releaseTags returns every tag with the given prefix that is followed by a
+// semantic version. Other tags are ignored.
+func (g *Git) releaseTags(prefix string) ([]*ReleaseTag, error) {
+	tags, err := g.repo.Tags()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to list tags")
+	}
+
+	var releases []*ReleaseTag
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+
+		v, err := semver.NewVersion(name[len(prefix):])
+		if err != nil {
+			return nil //nolint:nilerr // not a release tag, so skip it
+		}
+
+		hash, err := g.repo.ResolveRevision(plumbing.Revision(ref.Name()))
+		if err != nil {
+			return format.WrapErr(err, "unable to resolve tag %q", name)
+		}
+
+		releases = append(releases, &ReleaseTag{
+			Name:    name,
+			Version: v,
+			Commit:  *hash,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// LatestReleaseTag returns the tag with the given prefix that has the highest
+// semantic version. Tags with the prefix that are not followed by a semantic
+// version are ignored. Returns nil if no release tag is found.
+func (g *Git) LatestReleaseTag(prefix string) (*ReleaseTag, error) {
+	tags, err := g.releaseTags(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *ReleaseTag
+	for _, tag := range tags {
+		if latest == nil || latest.Version.LessThan(*tag.Version) {
+			latest = tag
+		}
+	}
+
+	return latest, nil
+}
+
+// NearestReleaseTag returns the release tag with the given prefix that is the
+// fewest commits away from HEAD among its ancestors, as git describe --tags
+// would choose it, along with the number of commits reachable from HEAD that
+// are not reachable from that tag. When several tags are equally near, the
+// one with the highest semantic version is returned. If no ancestor of HEAD is
+// tagged, it returns nil and the number of commits reachable from HEAD. Unlike
+// CommitsSince, the count is never limited to the module directory.
+func (g *Git) NearestReleaseTag(prefix string) (*ReleaseTag, int, error) {
+	tags, err := g.releaseTags(prefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tagged := make(map[plumbing.Hash]*ReleaseTag, len(tags))
+	for _, tag := range tags {
+		if other := tagged[tag.Commit]; other == nil || other.Version.LessThan(*tag.Version) {
+			tagged[tag.Commit] = tag
+		}
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return nil, 0, format.WrapErr(err, "unable to find HEAD")
+	}
+
+	// search the ancestors of HEAD one generation at a time
+	var nearest *ReleaseTag
+	seen := map[plumbing.Hash]struct{}{head.Hash(): {}}
+	generation := []plumbing.Hash{head.Hash()}
+	for nearest == nil && len(generation) > 0 {
+		var parents []plumbing.Hash
+		for _, hash := range generation {
+			if tag := tagged[hash]; tag != nil {
+				if nearest == nil || nearest.Version.LessThan(*tag.Version) {
+					nearest = tag
+				}
+				continue
+			}
+
+			c, err := g.repo.CommitObject(hash)
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				continue // the history of a shallow clone ends here
+			} else if err != nil {
+				return nil, 0, format.WrapErr(err, "unable to read commit %s", hash)
+			}
+
+			for _, parent := range c.ParentHashes {
+				if _, isSeen := seen[parent]; !isSeen {
+					seen[parent] = struct{}{}
+					parents = append(parents, parent)
+				}
+			}
+		}
+		generation = parents
+	}
+
+	old := map[plumbing.Hash]struct{}{}
+	if nearest != nil {
+		old, err = g.ancestors(nearest.Commit)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	all, err := g.ancestors(head.Hash())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count := 0
+	for hash := range all {
+		if _, isOld := old[hash]; !isOld {
+			count++
+		}
+	}
+
+	return nearest, count, nil
+}
+
+// ancestors returns the set of commits reachable from the given commit,
+// including the commit itself.
+func (g *Git) ancestors(from plumbing.Hash) (map[plumbing.Hash]struct{}, error) {
+	log, err := g.repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read history of %s", from)
+	}
+
+	commits := map[plumbing.Hash]struct{}{}
+	err = log.ForEach(func(c *object.Commit) error {
+		commits[c.Hash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read history of %s", from)
+	}
+
+	return commits, nil
+}
+
+// CommitsSince returns the commits reachable from HEAD that are not reachable
+// from the given commit, newest first. If the given hash is the zero hash, all
+// commits reachable from HEAD are returned. When the goal is scoped to a module
+// in a subdirectory (see module.dir), only the commits that change files in
+// that directory are returned.
+func (g *Git) CommitsSince(since plumbing.Hash) ([]*object.Commit, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to find HEAD")
+	}
+
+	seen := map[plumbing.Hash]struct{}{}
+	if !since.IsZero() {
+		seen, err = g.ancestors(since)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := &git.LogOptions{From: head.Hash()}
+	if g.moduleDir != "" && g.moduleDir != "." {
+		prefix := g.moduleDir + "/"
+		opts.PathFilter = func(name string) bool {
+			return strings.HasPrefix(name, prefix)
+		}
+	}
+
+	log, err := g.repo.Log(opts)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read history of HEAD")
+	}
+
+	commits := []*object.Commit{}
+	err = log.ForEach(func(c *object.Commit) error {
+		if _, isOld := seen[c.Hash]; isOld {
+			return nil
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read history of HEAD")
+	}
+
+	return commits, nil
+}
+
+// CommitsSinceRelease returns the commits made since the latest release tag
+// with the given prefix, newest first, along with that tag. If there is no
+// release tag, all commits reachable from HEAD are returned with a nil tag.
+func (g *Git) CommitsSinceRelease(prefix string) ([]*object.Commit, *ReleaseTag, error) {
+	tag, err := g.LatestReleaseTag(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	since := plumbing.ZeroHash
+	if tag != nil {
+		since = tag.Commit
+	}
+
+	commits, err := g.CommitsSince(since)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commits, tag, nil
+}
+
+// HeadBranch returns the name of the branch checked out at HEAD and the commit
+// at HEAD. It fails if HEAD is detached.
+func (g *Git) HeadBranch() (string, *object.Commit, error) {
+	head, err := g.backend.Head()
+	if err != nil {
+		return "", nil, format.WrapErr(err, "unable to read HEAD")
+	}
+
+	if !head.Name().IsBranch() {
+		return "", nil, fmt.Errorf("HEAD is detached, not on a branch")
+	}
+
+	commit, err := g.repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", nil, format.WrapErr(err, "unable to read HEAD commit")
+	}
+
+	return head.Name().Short(), commit, nil
+}
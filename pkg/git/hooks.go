@@ -0,0 +1,195 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+)
+
+const (
+	// hookMarker identifies the hooks installed by zedpm.
+	hookMarker = "# Installed by zedpm. Remove with: zedpm hooks uninstall"
+
+	// ChainedHookSuffix is added to the name of an existing hook when zedpm
+	// installs its own hook in its place. The zedpm hook runs the chained hook
+	// first.
+	ChainedHookSuffix = ".zedpm-chained"
+)
+
+// HooksDir returns the hooks directory of the git repository containing the
+// given directory. The git command is asked for the directory, so linked
+// worktrees, submodules, and core.hooksPath are all taken into account.
+func HooksDir(dir string) (string, error) {
+	out, err := (&CLI{dir}).git("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", format.WrapErr(err, "unable to find a git repository containing %s", dir)
+	}
+
+	hooksDir := strings.TrimSpace(out)
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+
+	return filepath.Abs(hooksDir)
+}
+
+// hookScript returns the shell script for the named hook that runs the chained
+// hook, if any, followed by the command.
+func hookScript(name, command string) string {
+	// pre-push reads the refs being pushed from stdin, so both the chained
+	// hook and the command need a copy of it
+	if name == "pre-push" {
+		return fmt.Sprintf(`#!/bin/sh
+%s
+input=$(cat)
+if [ -x "$0%s" ]; then
+    printf '%%s\n' "$input" | "$0%s" "$@" || exit $?
+fi
+printf '%%s\n' "$input" | {
+%s
+}
+`, hookMarker, ChainedHookSuffix, ChainedHookSuffix, command)
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+%s
+if [ -x "$0%s" ]; then
+    "$0%s" "$@" || exit $?
+fi
+%s
+`, hookMarker, ChainedHookSuffix, ChainedHookSuffix, command)
+}
+
+// isZedpmHook returns true if the named file is a hook installed by zedpm.
+func isZedpmHook(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return bytes.Contains(data, []byte(hookMarker)), nil
+}
+
+// exists returns true if the named file exists.
+func exists(path string) (bool, error) {
+	_, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// InstallHooks writes a hook into the hooks directory for each of the given
+// commands, which are keyed by hook name. An existing hook that was not
+// installed by zedpm is renamed with the ChainedHookSuffix and is run before
+// the command. Reinstalling replaces the hooks previously installed by zedpm.
+func InstallHooks(hooksDir string, commands map[string]string) error {
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return format.WrapErr(err, "unable to create hooks directory")
+	}
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(hooksDir, name)
+
+		isOurs, err := isZedpmHook(path)
+		if err != nil {
+			return format.WrapErr(err, "unable to read hook %s", name)
+		}
+
+		hasHook, err := exists(path)
+		if err != nil {
+			return format.WrapErr(err, "unable to check hook %s", name)
+		}
+
+		if hasHook && !isOurs {
+			chained := path + ChainedHookSuffix
+			hasChained, err := exists(chained)
+			if err != nil {
+				return format.WrapErr(err, "unable to check hook %s", name+ChainedHookSuffix)
+			}
+
+			if hasChained {
+				return fmt.Errorf("unable to chain hook %s because %s already exists", name, name+ChainedHookSuffix)
+			}
+
+			if err := os.Rename(path, chained); err != nil {
+				return format.WrapErr(err, "unable to chain hook %s", name)
+			}
+		}
+
+		err = os.WriteFile(path, []byte(hookScript(name, commands[name])), 0o755) //nolint:gosec // hooks must be executable
+		if err != nil {
+			return format.WrapErr(err, "unable to write hook %s", name)
+		}
+
+		// WriteFile does not change the mode of an existing file
+		if err := os.Chmod(path, 0o755); err != nil { //nolint:gosec // hooks must be executable
+			return format.WrapErr(err, "unable to make hook %s executable", name)
+		}
+	}
+
+	return nil
+}
+
+// UninstallHooks removes the hooks installed by zedpm from the hooks directory
+// and restores any hooks they chained. Hooks not installed by zedpm are left
+// alone.
+func UninstallHooks(hooksDir string) error {
+	entries, err := os.ReadDir(hooksDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return format.WrapErr(err, "unable to read hooks directory")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		path := filepath.Join(hooksDir, name)
+
+		isOurs, err := isZedpmHook(path)
+		if err != nil {
+			return format.WrapErr(err, "unable to read hook %s", name)
+		}
+
+		if !isOurs {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return format.WrapErr(err, "unable to remove hook %s", name)
+		}
+
+		chained := path + ChainedHookSuffix
+		hasChained, err := exists(chained)
+		if err != nil {
+			return format.WrapErr(err, "unable to check hook %s", name+ChainedHookSuffix)
+		}
+
+		if hasChained {
+			if err := os.Rename(chained, path); err != nil {
+				return format.WrapErr(err, "unable to restore hook %s", name)
+			}
+		}
+	}
+
+	return nil
+}
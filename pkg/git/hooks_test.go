@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runHook runs the named hook with the given argument and input, returning
+// its output.
+func runHook(t *testing.T, path, arg, input string) (string, error) {
+	t.Helper()
+
+	cmd := exec.Command(path, arg)
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestInstallHooks(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	dir := filepath.Join(t.TempDir(), "hooks")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	original := "#!/bin/sh\necho original \"$1\"\nexit ${FAIL:-0}\n"
+	preCommit := filepath.Join(dir, "pre-commit")
+	require.NoError(t, os.WriteFile(preCommit, []byte(original), 0o755)) //nolint:gosec // hooks must be executable
+
+	commands := map[string]string{
+		"pre-commit": `echo zedpm "$1"`,
+		"pre-push":   `echo pushing "$(cat)"`,
+	}
+	require.NoError(t, InstallHooks(dir, commands))
+
+	// reinstalling must not chain the zedpm hook to itself
+	require.NoError(t, InstallHooks(dir, commands))
+
+	out, err := runHook(t, preCommit, "x", "")
+	require.NoError(t, err)
+	assert.Equal(t, "original x\nzedpm x\n", out)
+
+	out, err = runHook(t, filepath.Join(dir, "pre-push"), "origin", "refs/heads/master")
+	require.NoError(t, err)
+	assert.Equal(t, "pushing refs/heads/master\n", out)
+
+	// a failing chained hook stops the hook
+	chained, err := os.ReadFile(preCommit + ChainedHookSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(chained))
+	require.NoError(t, os.WriteFile(preCommit+ChainedHookSuffix, []byte("#!/bin/sh\nexit 3\n"), 0o755)) //nolint:gosec // hooks must be executable
+	_, err = runHook(t, preCommit, "x", "")
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode())
+	require.NoError(t, os.WriteFile(preCommit+ChainedHookSuffix, []byte(original), 0o755)) //nolint:gosec // hooks must be executable
+
+	require.NoError(t, UninstallHooks(dir))
+
+	restored, err := os.ReadFile(preCommit)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(restored))
+	assert.NoFileExists(t, preCommit+ChainedHookSuffix)
+	assert.NoFileExists(t, filepath.Join(dir, "pre-push"))
+}
+
+func TestHooksDir(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := initRepo(t)
+	repo, err := filepath.EvalSymlinks(repo)
+	require.NoError(t, err)
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	hooksDir := func(dir string) string {
+		t.Helper()
+		hooksDir, err := HooksDir(dir)
+		require.NoError(t, err)
+		return hooksDir
+	}
+
+	sub := filepath.Join(repo, "sub")
+	require.NoError(t, os.MkdirAll(sub, 0o755))
+	assert.Equal(t, filepath.Join(repo, ".git", "hooks"), hooksDir(repo))
+	assert.Equal(t, filepath.Join(repo, ".git", "hooks"), hooksDir(sub))
+
+	// a linked worktree shares the hooks of the main repository
+	worktree := filepath.Join(filepath.Dir(repo), filepath.Base(repo)+"-worktree")
+	run(repo, "worktree", "add", "-q", "-b", "feature", worktree)
+	assert.Equal(t, filepath.Join(repo, ".git", "hooks"), hooksDir(worktree))
+
+	// a submodule has hooks of its own in the git directory of the superproject
+	lib, _ := initRepo(t)
+	run(repo, "-c", "protocol.file.allow=always", "submodule", "add", "-q", lib, "lib")
+	assert.Equal(t, filepath.Join(repo, ".git", "modules", "lib", "hooks"), hooksDir(filepath.Join(repo, "lib")))
+
+	run(repo, "config", "core.hooksPath", ".githooks")
+	assert.Equal(t, filepath.Join(repo, ".githooks"), hooksDir(sub))
+
+	_, err = HooksDir(t.TempDir())
+	assert.Error(t, err)
+}
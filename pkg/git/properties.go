@@ -2,6 +2,10 @@ package git
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
 
 	"github.com/zostay/zedpm/format"
 	"github.com/zostay/zedpm/pkg/goals"
@@ -15,6 +19,44 @@ const (
 	PropertyGitReleaseTag    = "git.release.tag"
 	PropertyGitReleaseBranch = "git.release.branch"
 	PropertyGitTargetBranch  = "git.target.branch"
+
+	// PropertyLegacyTargetBranch is the name git.target.branch had in earlier
+	// versions of the git plugin. It is still read if git.target.branch is not
+	// set.
+	PropertyLegacyTargetBranch = "target_branch"
+
+	PropertyGitPreReleaseBranches = "git.prerelease.branches"
+
+	PropertyGitRemote      = "git.remote"
+	PropertyGitPushRemotes = "git.pushRemotes"
+	DefaultGitRemote       = "origin"
+
+	PropertyGitBackend = "git.backend"
+	DefaultGitBackend  = "go-git"
+
+	PropertyGitSignFormat = "git.sign.format"
+	PropertyGitSignKey    = "git.sign.key"
+
+	// EnvGitSignPassphrase names the environment variable holding the
+	// passphrase of an encrypted signing key. The passphrase is not a property
+	// so that it never ends up in configuration files or info output.
+	EnvGitSignPassphrase = "ZEDPM_GIT_SIGN_PASSPHRASE"
+
+	PropertyGitHead            = "git.head"
+	PropertyGitBranch          = "git.branch"
+	PropertyGitLastTag         = "git.lastTag"
+	PropertyGitCommitsSinceTag = "git.commitsSinceTag"
+	PropertyGitDirty           = "git.isDirty"
+	PropertyGitDescribe        = "git.describe"
+
+	// PropertyGitDirtyIgnore lists the patterns of files that never make the
+	// working copy dirty. The value reported by /info/vcs/git is named
+	// git.isDirty rather than git.dirty so that it does not replace this one.
+	PropertyGitDirtyIgnore = "git.dirty.ignore"
+
+	// DefaultGitPreReleaseBranches lists the branch patterns from which
+	// pre-releases may be tagged when none are configured.
+	DefaultGitPreReleaseBranches = "release/*,release-*"
 )
 
 func GetPropertyGitReleaseTag(ctx context.Context) (string, error) {
@@ -37,14 +79,132 @@ func GetPropertyGitReleaseBranch(ctx context.Context) (string, error) {
 		return "", format.WrapErr(err, "unable to find or compute %q setting", PropertyGitReleaseBranch)
 	}
 
-	return PropertyReleaseBranchPrefix + version, nil
+	prefix := PropertyReleaseBranchPrefix
+	if dir := goals.GetPropertyModuleDir(ctx); dir != "." {
+		prefix = "release-" + strings.ReplaceAll(dir, "/", "-") + "-v"
+	}
+
+	return prefix + version, nil
 }
 
+// GetPropertyGitTargetBranch returns the name of the branch releases are made
+// from and merged into, which is set by git.target.branch (or the older
+// target_branch) and defaults to DefaultGitTargetBranch.
 func GetPropertyGitTargetBranch(ctx context.Context) string {
-	branch := plugin.GetString(ctx, PropertyGitTargetBranch)
-	if branch != "" {
-		return branch
+	for _, key := range []string{PropertyGitTargetBranch, PropertyLegacyTargetBranch} {
+		if branch := plugin.GetString(ctx, key); branch != "" {
+			return branch
+		}
 	}
 
 	return DefaultGitTargetBranch
 }
+
+// GetPropertyGitPreReleaseBranches returns the list of glob patterns naming the
+// branches from which a pre-release may be tagged. The patterns are separated
+// by commas in git.prerelease.branches.
+func GetPropertyGitPreReleaseBranches(ctx context.Context) []string {
+	value := plugin.GetString(ctx, PropertyGitPreReleaseBranches)
+	if value == "" {
+		value = DefaultGitPreReleaseBranches
+	}
+
+	patterns := []string{}
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// GetPropertyGitDirtyIgnore returns the glob patterns, in .gitignore syntax,
+// listed in the comma-separated git.dirty.ignore property. Files matching these
+// never make the working copy dirty.
+func GetPropertyGitDirtyIgnore(ctx context.Context) []string {
+	patterns := []string{}
+	for _, pattern := range strings.Split(plugin.GetString(ctx, PropertyGitDirtyIgnore), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// GetPropertyGitRemote returns the name of the remote the release is checked
+// against, which defaults to DefaultGitRemote.
+func GetPropertyGitRemote(ctx context.Context) string {
+	if remote := plugin.GetString(ctx, PropertyGitRemote); remote != "" {
+		return remote
+	}
+	return DefaultGitRemote
+}
+
+// GetPropertyGitPushRemotes returns the names of the remotes the release
+// branch and tag are pushed to, read from the comma-separated list in
+// git.pushRemotes. It defaults to the remote named by git.remote.
+func GetPropertyGitPushRemotes(ctx context.Context) []string {
+	remotes := []string{}
+	for _, remote := range strings.Split(plugin.GetString(ctx, PropertyGitPushRemotes), ",") {
+		if remote = strings.TrimSpace(remote); remote != "" {
+			remotes = append(remotes, remote)
+		}
+	}
+
+	if len(remotes) == 0 {
+		remotes = append(remotes, GetPropertyGitRemote(ctx))
+	}
+
+	return remotes
+}
+
+// CheckPreReleaseBranch returns an error if the release is a pre-release and
+// the target branch, which is the branch the release is tagged from, does not
+// match any of the patterns listed in git.prerelease.branches. This prevents
+// release candidates from being tagged off of a non-release branch.
+func CheckPreReleaseBranch(ctx context.Context) error {
+	if !goals.GetPropertyReleasePreRelease(ctx) {
+		return nil
+	}
+
+	branch := GetPropertyGitTargetBranch(ctx)
+	patterns := GetPropertyGitPreReleaseBranches(ctx)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("a pre-release may not be tagged from branch %q, only from a release branch matching one of: %s",
+		branch, strings.Join(patterns, ", "))
+}
+
+// GetPropertyGitBackend returns the name of the git backend to use, which
+// defaults to DefaultGitBackend.
+func GetPropertyGitBackend(ctx context.Context) string {
+	if backend := plugin.GetString(ctx, PropertyGitBackend); backend != "" {
+		return backend
+	}
+	return DefaultGitBackend
+}
+
+// GetPropertyGitSigning returns the settings for signing release commits and
+// tags from git.sign.format, git.sign.key, and the ZEDPM_GIT_SIGN_PASSPHRASE
+// environment variable. It returns nil if git.sign.format is not set, which
+// means nothing will be signed.
+func GetPropertyGitSigning(ctx context.Context) (*Signing, error) {
+	signFormat := plugin.GetString(ctx, PropertyGitSignFormat)
+	switch signFormat {
+	case "":
+		return nil, nil
+	case SignFormatOpenPGP, SignFormatSSH:
+	default:
+		return nil, fmt.Errorf("unknown %s %q (expected %q or %q)", PropertyGitSignFormat, signFormat, SignFormatOpenPGP, SignFormatSSH)
+	}
+
+	return &Signing{
+		Format:     signFormat,
+		Key:        plugin.GetString(ctx, PropertyGitSignKey),
+		Passphrase: os.Getenv(EnvGitSignPassphrase),
+	}, nil
+}
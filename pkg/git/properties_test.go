@@ -0,0 +1,176 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func propertyContext(values map[string]string) context.Context {
+	kv := storage.New()
+	kv.UpdateStrings(values)
+	return plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+}
+
+func TestGetPropertyGitPushRemotes(t *testing.T) {
+	t.Parallel()
+
+	ctx := propertyContext(map[string]string{})
+	assert.Equal(t, "origin", GetPropertyGitRemote(ctx))
+	assert.Equal(t, []string{"origin"}, GetPropertyGitPushRemotes(ctx))
+
+	ctx = propertyContext(map[string]string{
+		PropertyGitRemote: "upstream",
+	})
+	assert.Equal(t, "upstream", GetPropertyGitRemote(ctx))
+	assert.Equal(t, []string{"upstream"}, GetPropertyGitPushRemotes(ctx))
+
+	ctx = propertyContext(map[string]string{
+		PropertyGitRemote:      "upstream",
+		PropertyGitPushRemotes: "upstream, mirror,",
+	})
+	assert.Equal(t, []string{"upstream", "mirror"}, GetPropertyGitPushRemotes(ctx))
+}
+
+func TestGetPropertyGitReleaseBranchAndTag(t *testing.T) {
+	t.Parallel()
+
+	ctx := propertyContext(map[string]string{
+		goals.PropertyReleaseVersion: "1.2.3",
+	})
+	branch, err := GetPropertyGitReleaseBranch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "release-v1.2.3", branch)
+	tag, err := GetPropertyGitReleaseTag(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.3", tag)
+
+	ctx = propertyContext(map[string]string{
+		goals.PropertyReleaseVersion: "1.2.3",
+		goals.PropertyModuleDir:      "tools/api",
+	})
+	branch, err = GetPropertyGitReleaseBranch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "release-tools-api-v1.2.3", branch)
+	tag, err = GetPropertyGitReleaseTag(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tools/api/v1.2.3", tag)
+}
+
+func TestGetPropertyGitTargetBranch(t *testing.T) {
+	t.Parallel()
+
+	ctx := propertyContext(map[string]string{})
+	assert.Equal(t, "master", GetPropertyGitTargetBranch(ctx))
+	assert.Equal(t, "refs/heads/master", string(TargetBranchRefName(ctx)))
+
+	ctx = propertyContext(map[string]string{
+		PropertyLegacyTargetBranch: "main",
+	})
+	assert.Equal(t, "main", GetPropertyGitTargetBranch(ctx))
+	assert.Equal(t, "main", TargetBranch(ctx))
+
+	ctx = propertyContext(map[string]string{
+		PropertyGitTargetBranch:    "release/1.2",
+		PropertyLegacyTargetBranch: "main",
+	})
+	assert.Equal(t, "release/1.2", GetPropertyGitTargetBranch(ctx))
+	assert.Equal(t, "refs/heads/release/1.2", string(TargetBranchRefName(ctx)))
+}
+
+func TestCheckPreReleaseBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		props    map[string]string
+		hasError bool
+	}{
+		{
+			name:  "release from master",
+			props: map[string]string{goals.PropertyReleaseVersion: "1.2.0"},
+		},
+		{
+			name:     "pre-release from master",
+			props:    map[string]string{goals.PropertyReleaseVersion: "1.2.0-rc.1"},
+			hasError: true,
+		},
+		{
+			name: "forced pre-release from master",
+			props: map[string]string{
+				goals.PropertyReleaseVersion:    "1.2.0",
+				goals.PropertyReleasePreRelease: "true",
+			},
+			hasError: true,
+		},
+		{
+			name: "pre-release from release branch",
+			props: map[string]string{
+				goals.PropertyReleaseVersion: "1.2.0-rc.1",
+				PropertyGitTargetBranch:      "release/1.2",
+			},
+		},
+		{
+			name: "pre-release from legacy target branch",
+			props: map[string]string{
+				goals.PropertyReleaseVersion: "1.2.0-rc.1",
+				PropertyLegacyTargetBranch:   "release-1.2",
+			},
+		},
+		{
+			name: "pre-release from unlisted branch",
+			props: map[string]string{
+				goals.PropertyReleaseVersion:  "1.2.0-rc.1",
+				PropertyGitTargetBranch:       "release/1.2",
+				PropertyGitPreReleaseBranches: "rc/*, next",
+			},
+			hasError: true,
+		},
+		{
+			name: "pre-release from listed branch",
+			props: map[string]string{
+				goals.PropertyReleaseVersion:  "1.2.0-rc.1",
+				PropertyGitTargetBranch:       "next",
+				PropertyGitPreReleaseBranches: "rc/*, next",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		err := CheckPreReleaseBranch(propertyContext(test.props))
+		if test.hasError {
+			assert.Error(t, err, test.name)
+		} else {
+			assert.NoError(t, err, test.name)
+		}
+	}
+}
+
+func TestGetPropertyGitSigning(t *testing.T) {
+	t.Setenv(EnvGitSignPassphrase, "secret")
+
+	signing, err := GetPropertyGitSigning(propertyContext(map[string]string{}))
+	assert.NoError(t, err)
+	assert.Nil(t, signing)
+
+	signing, err = GetPropertyGitSigning(propertyContext(map[string]string{
+		PropertyGitSignFormat: SignFormatOpenPGP,
+		PropertyGitSignKey:    "key.asc",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, &Signing{
+		Format:     SignFormatOpenPGP,
+		Key:        "key.asc",
+		Passphrase: "secret",
+	}, signing)
+
+	_, err = GetPropertyGitSigning(propertyContext(map[string]string{
+		PropertyGitSignFormat: "x509",
+	}))
+	assert.Error(t, err)
+}
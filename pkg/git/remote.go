@@ -0,0 +1,115 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// SplitRemoteURL splits a git remote URL into the host and the path of the
+// repository on that host. The path is returned without surrounding slashes or
+// ".git" suffix. It accepts URLs like https://host/owner/project.git,
+// ssh://git@host:port/owner/project.git, and the scp-like
+// git@host:owner/project.git.
+func SplitRemoteURL(remoteURL string) (host, repoPath string, err error) {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", format.WrapErr(err, "unable to parse remote URL %q", remoteURL)
+		}
+
+		host, repoPath = u.Hostname(), u.Path
+	} else {
+		var found bool
+		host, repoPath, found = strings.Cut(remoteURL, ":")
+		if !found {
+			return "", "", fmt.Errorf("remote URL %q has no host", remoteURL)
+		}
+
+		if _, h, hasUser := strings.Cut(host, "@"); hasUser {
+			host = h
+		}
+	}
+
+	if host == "" {
+		return "", "", fmt.Errorf("remote URL %q has no host", remoteURL)
+	}
+
+	repoPath = strings.Trim(repoPath, "/")
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+
+	return host, repoPath, nil
+}
+
+// Forge describes a service hosting git repositories, such as Github, so that
+// the owner and project of a repository can be found from its remote URL.
+type Forge struct {
+	// Name names the service in error messages, e.g., "Github".
+	Name string
+
+	// SplitPath splits the repository path returned by SplitRemoteURL into the
+	// owner and project. It returns false if the path does not name a
+	// repository on this service.
+	SplitPath func(repoPath string) (owner, project string, ok bool)
+}
+
+// ParseRemoteURL splits a git remote URL into the host, owner, and project of
+// a repository hosted by the forge.
+func (f *Forge) ParseRemoteURL(remoteURL string) (host, owner, project string, err error) {
+	host, repoPath, err := SplitRemoteURL(remoteURL)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	owner, project, ok := f.SplitPath(repoPath)
+	if !ok || owner == "" || project == "" {
+		return "", "", "", fmt.Errorf("remote URL %q does not look like a %s URL", remoteURL, f.Name)
+	}
+
+	return host, owner, project, nil
+}
+
+// OwnerProject fills in whichever of owner and project is empty from the first
+// URL of the remote, which must name a repository on the given host of the
+// forge. Hosts are compared case-insensitively. When neither owner nor project
+// is empty, they are returned as is and the remote is not consulted.
+func (f *Forge) OwnerProject(
+	remote *Remote,
+	host string,
+	owner string,
+	project string,
+) (string, string, error) {
+	if owner != "" && project != "" {
+		return owner, project, nil
+	}
+
+	errPrefix := fmt.Sprintf("unable to determine %s project and owner from git remote configuration", f.Name)
+	if remote == nil {
+		return owner, project, fmt.Errorf("%s: unable to load git remote client", errPrefix)
+	}
+
+	if len(remote.URLs) == 0 {
+		return owner, project, fmt.Errorf("%s: no remote URLs found", errPrefix)
+	}
+
+	remoteURL := remote.URLs[0]
+	urlHost, urlOwner, urlProject, err := f.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return owner, project, format.WrapErr(err, "%s", errPrefix)
+	}
+
+	if !strings.EqualFold(urlHost, host) {
+		return owner, project, fmt.Errorf("%s: remote URL %q is not on the %s host %q", errPrefix, remoteURL, f.Name, host)
+	}
+
+	if owner == "" {
+		owner = urlOwner
+	}
+	if project == "" {
+		project = urlProject
+	}
+
+	return owner, project, nil
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][2]string{
+		"git@example.com:zostay/zedpm.git":           {"example.com", "zostay/zedpm"},
+		"example.com:zostay/zedpm":                   {"example.com", "zostay/zedpm"},
+		"ssh://git@example.com:2222/a/b/zedpm.git":   {"example.com", "a/b/zedpm"},
+		"https://user@example.com/zostay/zedpm.git/": {"example.com", "zostay/zedpm"},
+		"https://Example.com/forge/zostay/zedpm":     {"Example.com", "forge/zostay/zedpm"},
+		"http://127.0.0.1:8080/zostay/zedpm.git":     {"127.0.0.1", "zostay/zedpm"},
+	}
+
+	for remoteURL, expect := range tests {
+		host, repoPath, err := SplitRemoteURL(remoteURL)
+		require.NoError(t, err, remoteURL)
+		assert.Equal(t, expect, [2]string{host, repoPath}, remoteURL)
+	}
+
+	for _, remoteURL := range []string{
+		"/srv/git/zedpm.git",
+		"file:///srv/git/zedpm.git",
+		":zostay/zedpm.git",
+		"https://%zz/zostay/zedpm",
+	} {
+		_, _, err := SplitRemoteURL(remoteURL)
+		assert.Error(t, err, remoteURL)
+	}
+}
+
+func TestForgeOwnerProject(t *testing.T) {
+	t.Parallel()
+
+	forge := &Forge{
+		Name: "Test",
+		SplitPath: func(repoPath string) (string, string, bool) {
+			return strings.Cut(repoPath, "/")
+		},
+	}
+	remote := &Remote{
+		Name: "origin",
+		URLs: []string{"git@Forge.Example.com:zostay/zedpm.git"},
+	}
+
+	owner, project, err := forge.OwnerProject(remote, "forge.example.com", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"zostay", "zedpm"}, [2]string{owner, project})
+
+	owner, project, err = forge.OwnerProject(remote, "forge.example.com", "", "other")
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"zostay", "other"}, [2]string{owner, project})
+
+	owner, project, err = forge.OwnerProject(nil, "forge.example.com", "me", "other")
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"me", "other"}, [2]string{owner, project})
+
+	_, _, err = forge.OwnerProject(nil, "forge.example.com", "me", "")
+	assert.EqualError(t, err, "unable to determine Test project and owner from git remote configuration: unable to load git remote client")
+
+	_, _, err = forge.OwnerProject(&Remote{Name: "origin"}, "forge.example.com", "", "")
+	assert.EqualError(t, err, "unable to determine Test project and owner from git remote configuration: no remote URLs found")
+
+	_, _, err = forge.OwnerProject(remote, "other.example.com", "", "")
+	assert.EqualError(t, err, `unable to determine Test project and owner from git remote configuration: remote URL "git@Forge.Example.com:zostay/zedpm.git" is not on the Test host "other.example.com"`)
+
+	_, _, err = forge.OwnerProject(&Remote{URLs: []string{"git@forge.example.com:zedpm.git"}}, "forge.example.com", "", "")
+	assert.EqualError(t, err, `unable to determine Test project and owner from git remote configuration: remote URL "git@forge.example.com:zedpm.git" does not look like a Test URL`)
+}
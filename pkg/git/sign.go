@@ -0,0 +1,105 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+
+	"github.com/zostay/zedpm/format"
+)
+
+const (
+	SignFormatOpenPGP = "openpgp" // sign with an OpenPGP key
+	SignFormatSSH     = "ssh"     // sign with an SSH key
+)
+
+// Signing describes how to sign release commits and tags.
+type Signing struct {
+	// Format is the kind of signature to make, either SignFormatOpenPGP or
+	// SignFormatSSH.
+	Format string
+
+	// Key names the key to sign with. For the go-git backend, this is the
+	// path to an armored OpenPGP private key. For the cli backend, it is used
+	// as the user.signingKey git setting: an OpenPGP key ID or the path to an
+	// SSH key. When empty, the cli backend uses the signing key configured
+	// in git.
+	Key string
+
+	// Passphrase is used to decrypt an encrypted OpenPGP private key for the
+	// go-git backend.
+	Passphrase string
+}
+
+// OpenPGPEntity reads the armored OpenPGP private key named by Key and
+// decrypts it with the Passphrase, if it is encrypted.
+func (s *Signing) OpenPGPEntity() (*openpgp.Entity, error) {
+	if s.Key == "" {
+		return nil, fmt.Errorf("no OpenPGP signing key file is configured")
+	}
+
+	f, err := os.Open(s.Key)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to open signing key %q", s.Key)
+	}
+	defer func() { _ = f.Close() }()
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read signing key %q", s.Key)
+	}
+
+	if len(keyRing) == 0 || keyRing[0].PrivateKey == nil {
+		return nil, fmt.Errorf("signing key %q does not contain a private key", s.Key)
+	}
+
+	entity := keyRing[0]
+	if entity.PrivateKey.Encrypted {
+		if err := entity.DecryptPrivateKeys([]byte(s.Passphrase)); err != nil {
+			return nil, format.WrapErr(err, "unable to decrypt signing key %q", s.Key)
+		}
+	}
+
+	return entity, nil
+}
+
+// armoredPublicKey returns the armored public key of the entity, which is
+// needed to verify signatures made with it.
+func armoredPublicKey(entity *openpgp.Entity) (string, error) {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if err := entity.Serialize(w); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// sshPublicKey returns the SSH public key that verifies signatures made with
+// Key. If Key names a private key, the public key is read from the file of
+// the same name with a .pub suffix.
+func (s *Signing) sshPublicKey() (string, error) {
+	name := s.Key
+	if !strings.HasSuffix(name, ".pub") {
+		name += ".pub"
+	}
+
+	pub, err := os.ReadFile(name)
+	if err != nil {
+		return "", format.WrapErr(err, "unable to read SSH public key %q", name)
+	}
+
+	return string(bytes.TrimSpace(pub)), nil
+}
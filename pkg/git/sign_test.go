@@ -0,0 +1,110 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOpenPGPKey generates an OpenPGP key encrypted with the passphrase and
+// writes it in armored form to a new file, whose name is returned.
+func writeOpenPGPKey(t *testing.T, passphrase string) string {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Test User", "", "test@example.com", nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.EncryptPrivateKeys([]byte(passphrase), nil))
+
+	name := filepath.Join(t.TempDir(), "key.asc")
+	f, err := os.Create(name)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	w, err := armor.Encode(f, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivateWithoutSigning(w, nil))
+	require.NoError(t, w.Close())
+
+	return name
+}
+
+// writeSSHKey generates an SSH key, returning the name of the private key.
+func writeSSHKey(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen command is not installed")
+	}
+
+	name := filepath.Join(t.TempDir(), "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", name).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return name
+}
+
+func testSigning(t *testing.T, backend string, good, bad *Signing) {
+	t.Helper()
+
+	dir, _ := initRepo(t)
+	b, err := OpenBackend(backend, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("b\n"), 0o644))
+	require.NoError(t, b.Add("a.txt"))
+
+	hash, err := b.Commit("signed", good)
+	require.NoError(t, err)
+	assert.NoError(t, b.VerifyCommit(hash, good))
+	assert.Error(t, b.VerifyCommit(hash, bad))
+
+	require.NoError(t, b.CreateTag("v1.0.0", hash, "Release tag", good))
+	assert.NoError(t, b.VerifyTag("v1.0.0", good))
+	assert.Error(t, b.VerifyTag("v1.0.0", bad))
+
+	require.NoError(t, b.CreateTag("v1.0.1", hash, "Release tag", nil))
+	assert.Error(t, b.VerifyTag("v1.0.1", good))
+}
+
+func TestGoGitOpenPGPSigning(t *testing.T) {
+	t.Parallel()
+
+	good := &Signing{
+		Format:     SignFormatOpenPGP,
+		Key:        writeOpenPGPKey(t, "secret"),
+		Passphrase: "secret",
+	}
+	bad := &Signing{
+		Format:     SignFormatOpenPGP,
+		Key:        writeOpenPGPKey(t, "other"),
+		Passphrase: "other",
+	}
+
+	testSigning(t, "go-git", good, bad)
+
+	wrong := *good
+	wrong.Passphrase = "wrong"
+	_, err := wrong.OpenPGPEntity()
+	assert.Error(t, err)
+
+	dir, _ := initRepo(t)
+	b, err := OpenBackend("go-git", dir)
+	require.NoError(t, err)
+	_, err = b.Commit("signed", &Signing{Format: SignFormatSSH})
+	assert.EqualError(t, err, "the go-git backend does not support ssh signing, use the cli backend instead")
+}
+
+func TestCLISSHSigning(t *testing.T) {
+	t.Parallel()
+
+	good := &Signing{Format: SignFormatSSH, Key: writeSSHKey(t)}
+	bad := &Signing{Format: SignFormatSSH, Key: writeSSHKey(t)}
+
+	testSigning(t, "cli", good, bad)
+}
@@ -0,0 +1,347 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// Client is a minimal client for the parts of the Gitea API (v1) used by zedpm.
+// Forgejo provides the same API.
+type Client struct {
+	baseURL *url.URL
+	token   string
+	hc      *http.Client
+}
+
+// NewClient returns a client for the Gitea or Forgejo instance at the given
+// base URL, e.g., https://codeberg.org. The /api/v1 path is added unless the
+// base URL already ends with it. If hc is nil, http.DefaultClient is used.
+func NewClient(baseURL, token string, hc *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to parse Gitea URL %q", baseURL)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("Gitea URL %q has no host", baseURL)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api/v1") {
+		u.Path += "/api/v1"
+	}
+	u.Path += "/"
+
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	return &Client{u, token, hc}, nil
+}
+
+// ErrorResponse is the error returned when the Gitea API responds with an
+// error status.
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the status and message of the response.
+func (e *ErrorResponse) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Gitea API error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("Gitea API error: %d %s", e.StatusCode, e.Message)
+}
+
+// send sends the request and decodes the JSON response into out, if not nil.
+func (c *Client) send(req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return format.WrapErr(err, "Gitea request failed")
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 300 {
+		var msg struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&msg)
+		return &ErrorResponse{StatusCode: res.StatusCode, Message: msg.Message}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return format.WrapErr(err, "unable to decode Gitea response")
+	}
+
+	return nil
+}
+
+// do sends a request to the API endpoint at the given path, relative to the API
+// base URL. The in value, if not nil, is sent as JSON and the JSON response is
+// decoded into out, if not nil.
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	query url.Values,
+	in any,
+	out any,
+) error {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return format.WrapErr(err, "unable to encode Gitea request")
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return format.WrapErr(err, "unable to create Gitea request")
+	}
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.send(req, out)
+}
+
+// repoPath returns the API path for the repository of the owner.
+func repoPath(owner, repo string, rest ...string) string {
+	return strings.Join(append([]string{"repos", url.PathEscape(owner), url.PathEscape(repo)}, rest...), "/")
+}
+
+// PRBranch is the head or base of a pull request.
+type PRBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// PullRequest is a Gitea pull request.
+type PullRequest struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title"`
+	Body    string   `json:"body"`
+	State   string   `json:"state"`
+	HTMLURL string   `json:"html_url"`
+	Merged  bool     `json:"merged"`
+	Head    PRBranch `json:"head"`
+	Base    PRBranch `json:"base"`
+}
+
+// NewPullRequest describes the pull request to create.
+type NewPullRequest struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// CreatePullRequest creates a pull request in the repository.
+func (c *Client) CreatePullRequest(
+	ctx context.Context,
+	owner, repo string,
+	pr *NewPullRequest,
+) (*PullRequest, error) {
+	var out PullRequest
+	err := c.do(ctx, http.MethodPost, repoPath(owner, repo, "pulls"), nil, pr, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FindPullRequest returns the open pull request of the repository with the
+// given head branch or nil if there is none.
+func (c *Client) FindPullRequest(
+	ctx context.Context,
+	owner, repo string,
+	head string,
+) (*PullRequest, error) {
+	const limit = 50
+	for page := 1; ; page++ {
+		var out []*PullRequest
+		query := url.Values{
+			"state": {"open"},
+			"page":  {strconv.Itoa(page)},
+			"limit": {strconv.Itoa(limit)},
+		}
+		err := c.do(ctx, http.MethodGet, repoPath(owner, repo, "pulls"), query, nil, &out)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range out {
+			if pr.Head.Ref == head {
+				return pr, nil
+			}
+		}
+
+		if len(out) < limit {
+			return nil, nil
+		}
+	}
+}
+
+// MergePullRequest merges the numbered pull request with a merge commit.
+func (c *Client) MergePullRequest(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	message string,
+) error {
+	in := map[string]any{"Do": "merge"}
+	if message != "" {
+		in["MergeMessageField"] = message
+	}
+	path := repoPath(owner, repo, "pulls", strconv.Itoa(number), "merge")
+	return c.do(ctx, http.MethodPost, path, nil, in, nil)
+}
+
+// Combined commit status states.
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusError   = "error"
+	StatusFailure = "failure"
+	StatusWarning = "warning"
+)
+
+// Status is the status of a single check on a commit.
+type Status struct {
+	Context     string `json:"context"`
+	State       string `json:"status"`
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+}
+
+// CombinedStatus is the combined status of all the checks on a commit.
+type CombinedStatus struct {
+	State      string    `json:"state"`
+	TotalCount int       `json:"total_count"`
+	Statuses   []*Status `json:"statuses"`
+}
+
+// GetCombinedStatus returns the combined status of the checks on the given
+// ref.
+func (c *Client) GetCombinedStatus(
+	ctx context.Context,
+	owner, repo string,
+	ref string,
+) (*CombinedStatus, error) {
+	var out CombinedStatus
+	path := repoPath(owner, repo, "commits", url.PathEscape(ref), "status")
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Release is a Gitea release.
+type Release struct {
+	ID              int64         `json:"id,omitempty"`
+	TagName         string        `json:"tag_name"`
+	TargetCommitish string        `json:"target_commitish,omitempty"`
+	Name            string        `json:"name"`
+	Body            string        `json:"body"`
+	Draft           bool          `json:"draft"`
+	Prerelease      bool          `json:"prerelease"`
+	Assets          []*Attachment `json:"assets,omitempty"`
+}
+
+// CreateRelease creates a release for the repository. If the tag does not
+// exist yet, Gitea creates it from TargetCommitish.
+func (c *Client) CreateRelease(
+	ctx context.Context,
+	owner, repo string,
+	rel *Release,
+) (*Release, error) {
+	var out Release
+	err := c.do(ctx, http.MethodPost, repoPath(owner, repo, "releases"), nil, rel, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Attachment is a file attached to a release.
+type Attachment struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// UploadReleaseAsset attaches the named file to the release.
+func (c *Client) UploadReleaseAsset(
+	ctx context.Context,
+	owner, repo string,
+	releaseID int64,
+	fileName string,
+) (*Attachment, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to open release asset %q", fileName)
+	}
+	defer func() { _ = f.Close() }()
+
+	name := filepath.Base(fileName)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("attachment", name)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to encode release asset %q", fileName)
+	}
+
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, format.WrapErr(err, "unable to read release asset %q", fileName)
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, format.WrapErr(err, "unable to encode release asset %q", fileName)
+	}
+
+	u := c.baseURL.JoinPath(repoPath(owner, repo, "releases", strconv.FormatInt(releaseID, 10), "assets"))
+	u.RawQuery = url.Values{"name": {name}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &body)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to create Gitea request")
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var out Attachment
+	if err := c.send(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,3 @@
+// Package gitea provides shared tooling to allow zedpm plugins to interact
+// with Gitea and Forgejo in a consistent way.
+package gitea
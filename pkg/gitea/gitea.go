@@ -0,0 +1,86 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+)
+
+// Gitea provides a client object for accessing the Gitea or Forgejo API.
+type Gitea struct {
+	git.Git
+	gt *Client
+}
+
+func (g *Gitea) Client() *Client {
+	return g.gt
+}
+
+// SetupGiteaClient opens the git repository and configures the Gitea client
+// for the instance at gitea.baseURL with the token in GITEA_TOKEN.
+func (g *Gitea) SetupGiteaClient(ctx context.Context) error {
+	err := g.Git.SetupGitRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseURL := GetPropertyGiteaBaseURL(ctx)
+	if baseURL == "" {
+		return fmt.Errorf("the %s property is missing", PropertyGiteaBaseURL)
+	}
+
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GITEA_TOKEN environment variable is missing")
+	}
+
+	g.gt, err = NewClient(baseURL, token, nil)
+	return err
+}
+
+// Forge describes Gitea to the shared git remote URL handling. The owner and
+// project are the last two elements of the repository path, which allows for
+// instances served under a sub-path.
+var Forge = &git.Forge{
+	Name: "Gitea",
+	SplitPath: func(repoPath string) (string, string, bool) {
+		parts := strings.Split(repoPath, "/")
+		if len(parts) < 2 {
+			return "", "", false
+		}
+		return parts[len(parts)-2], parts[len(parts)-1], true
+	},
+}
+
+// ParseRemoteURL splits a git remote URL into the host, owner, and project of
+// a Gitea repository. It accepts URLs like https://host/owner/project.git,
+// ssh://git@host:port/owner/project.git, and the scp-like
+// git@host:owner/project.git. For instances served under a sub-path, e.g.,
+// https://host/gitea/owner/project.git, the last two path elements are used.
+func ParseRemoteURL(remoteURL string) (host, owner, project string, err error) {
+	return Forge.ParseRemoteURL(remoteURL)
+}
+
+// OwnerProject returns the owner and project of the Gitea repository. These are
+// taken from gitea.owner and gitea.project or, when those are not set, from the
+// first URL of the git remote, which must be on the host of gitea.baseURL.
+func (g *Gitea) OwnerProject(ctx context.Context) (string, string, error) {
+	owner := GetPropertyGiteaOwner(ctx)
+	project := GetPropertyGiteaProject(ctx)
+
+	if owner != "" && project != "" {
+		return owner, project, nil
+	}
+
+	baseURL, err := url.Parse(GetPropertyGiteaBaseURL(ctx))
+	if err != nil {
+		return owner, project, format.WrapErr(err, "unable to parse %q", PropertyGiteaBaseURL)
+	}
+
+	return Forge.OwnerProject(g.Remote(), baseURL.Hostname(), owner, project)
+}
@@ -0,0 +1,80 @@
+package gitea
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][3]string{
+		"git@codeberg.org:zostay/zedpm.git":               {"codeberg.org", "zostay", "zedpm"},
+		"https://codeberg.org/zostay/zedpm":               {"codeberg.org", "zostay", "zedpm"},
+		"ssh://git@git.example.com:2222/tools/zedpm.git":  {"git.example.com", "tools", "zedpm"},
+		"https://git.example.com/forgejo/tools/zedpm.git": {"git.example.com", "tools", "zedpm"},
+	}
+
+	for remoteURL, expect := range tests {
+		host, owner, project, err := ParseRemoteURL(remoteURL)
+		require.NoError(t, err, remoteURL)
+		assert.Equal(t, expect, [3]string{host, owner, project}, remoteURL)
+	}
+
+	for _, remoteURL := range []string{
+		"/srv/git/zedpm.git",
+		"https://codeberg.org/zedpm",
+		"git@codeberg.org:zostay/",
+	} {
+		_, _, _, err := ParseRemoteURL(remoteURL)
+		assert.Error(t, err, remoteURL)
+	}
+}
+
+func TestUploadReleaseAsset(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"token is required"}`))
+			return
+		}
+
+		assert.Equal(t, "/forge/api/v1/repos/zostay/zedpm/releases/3/assets", r.URL.Path)
+		assert.Equal(t, "a.zip", r.URL.Query().Get("name"))
+
+		f, hdr, err := r.FormFile("attachment")
+		require.NoError(t, err)
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "zip!", string(data))
+
+		_, _ = w.Write([]byte(`{"id":9,"name":"` + hdr.Filename + `","size":4}`))
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "a.zip")
+	require.NoError(t, os.WriteFile(file, []byte("zip!"), 0o644))
+
+	c, err := NewClient(srv.URL+"/forge", "secret", nil)
+	require.NoError(t, err)
+
+	att, err := c.UploadReleaseAsset(context.Background(), "zostay", "zedpm", 3, file)
+	require.NoError(t, err)
+	assert.Equal(t, &Attachment{ID: 9, Name: "a.zip", Size: 4}, att)
+
+	c, err = NewClient(srv.URL+"/forge/api/v1/", "", nil)
+	require.NoError(t, err)
+
+	_, err = c.UploadReleaseAsset(context.Background(), "zostay", "zedpm", 3, file)
+	assert.EqualError(t, err, "Gitea API error: 401 token is required")
+}
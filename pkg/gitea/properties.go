@@ -0,0 +1,70 @@
+package gitea
+
+import (
+	"context"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+const (
+	PropertyGiteaBaseURL        = "gitea.baseURL"
+	PropertyGiteaOwner          = "gitea.owner"
+	PropertyGiteaProject        = "gitea.project"
+	PropertyGiteaReleaseName    = "gitea.release.name"
+	PropertyGiteaStatusTimeout  = "gitea.status.timeout"
+	PropertyGiteaStatusInterval = "gitea.status.interval"
+)
+
+const (
+	defaultReleaseNamePrefix = "Release v"
+	defaultStatusTimeout     = 15 * time.Minute
+	defaultStatusInterval    = 30 * time.Second
+)
+
+// GetPropertyGiteaBaseURL returns the URL of the Gitea or Forgejo instance.
+// There is no default.
+func GetPropertyGiteaBaseURL(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGiteaBaseURL)
+}
+
+func GetPropertyGiteaOwner(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGiteaOwner)
+}
+
+func GetPropertyGiteaProject(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGiteaProject)
+}
+
+func GetPropertyGiteaReleaseName(ctx context.Context) (string, error) {
+	if plugin.IsSet(ctx, PropertyGiteaReleaseName) {
+		return plugin.GetString(ctx, PropertyGiteaReleaseName), nil
+	}
+
+	version, err := goals.GetPropertyReleaseVersion(ctx)
+	if err != nil {
+		return "", format.WrapErr(err, "unable to get or create a value for %q", PropertyGiteaReleaseName)
+	}
+
+	return defaultReleaseNamePrefix + version, nil
+}
+
+// GetPropertyGiteaStatusTimeout returns how long to wait for the status checks
+// of the pull request to succeed, which defaults to 15 minutes.
+func GetPropertyGiteaStatusTimeout(ctx context.Context) time.Duration {
+	if timeout := plugin.GetDuration(ctx, PropertyGiteaStatusTimeout); timeout > 0 {
+		return timeout
+	}
+	return defaultStatusTimeout
+}
+
+// GetPropertyGiteaStatusInterval returns how long to wait between checks of
+// the status of the pull request, which defaults to 30 seconds.
+func GetPropertyGiteaStatusInterval(ctx context.Context) time.Duration {
+	if interval := plugin.GetDuration(ctx, PropertyGiteaStatusInterval); interval > 0 {
+		return interval
+	}
+	return defaultStatusInterval
+}
@@ -3,12 +3,15 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/google/go-github/v49/github"
 	"golang.org/x/oauth2"
 
+	"github.com/zostay/zedpm/format"
 	"github.com/zostay/zedpm/pkg/git"
 )
 
@@ -38,17 +41,94 @@ func (g *Github) SetupGithubClient(ctx context.Context) error {
 	)
 	tc := oauth2.NewClient(ctx, ts)
 
-	g.gh = github.NewClient(tc)
+	baseURL := GetPropertyGithubBaseURL(ctx)
+	if baseURL == "" {
+		g.gh = github.NewClient(tc)
+		return nil
+	}
+
+	g.gh, err = newEnterpriseClient(baseURL, GetPropertyGithubUploadURL(ctx), tc)
+	if err != nil {
+		return format.WrapErr(err, "unable to configure Github client for %q", baseURL)
+	}
 
 	return nil
 }
 
-var githubPrefixes = []string{
-	"git@github.com:",
-	"ssh://git@github.com/",
-	"https://github.com/",
+// newEnterpriseClient returns a client for the Github API at the given base
+// URL that uploads release assets to the given upload URL. Unlike
+// github.NewEnterpriseClient, it does not add api/uploads/ to an upload URL
+// whose host starts with "uploads.", since such hosts serve uploads from the
+// root.
+func newEnterpriseClient(baseURL, uploadURL string, hc *http.Client) (*github.Client, error) {
+	gh, err := github.NewEnterpriseClient(baseURL, uploadURL, hc)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(u.Host, "uploads.") {
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		gh.UploadURL = u
+	}
+
+	return gh, nil
+}
+
+// defaultHost is the host of the remote URLs of projects hosted on github.com.
+const defaultHost = "github.com"
+
+// RemoteHost returns the host expected in the git remote URLs of projects
+// served by the Github API at the given base URL. For an empty base URL, this
+// is github.com. Otherwise, it is the host of the base URL, without any "api."
+// prefix, as used by some Github Enterprise installations.
+func RemoteHost(baseURL string) (string, error) {
+	if baseURL == "" {
+		return defaultHost, nil
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", format.WrapErr(err, "unable to parse Github API URL %q", baseURL)
+	}
+
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("Github API URL %q has no host", baseURL)
+	}
+
+	return strings.TrimPrefix(u.Hostname(), "api."), nil
+}
+
+// Forge describes Github to the shared git remote URL handling. The path of a
+// Github repository is exactly the owner and project.
+var Forge = &git.Forge{
+	Name: "Github",
+	SplitPath: func(repoPath string) (string, string, bool) {
+		parts := strings.Split(repoPath, "/")
+		if len(parts) != 2 {
+			return "", "", false
+		}
+		return parts[0], parts[1], true
+	},
+}
+
+// ParseRemoteURL splits a git remote URL into the host, owner, and project of
+// a Github repository. It accepts URLs like https://host/owner/project.git,
+// ssh://git@host:port/owner/project.git, and the scp-like git@host:owner/project.git.
+func ParseRemoteURL(remoteURL string) (host, owner, project string, err error) {
+	return Forge.ParseRemoteURL(remoteURL)
 }
 
+// OwnerProject returns the owner and project of the Github repository. These
+// are taken from github.owner and github.project or, when those are not set,
+// from the first URL of the git remote, which must name a repository on the
+// host of the Github API.
 func (g *Github) OwnerProject(ctx context.Context) (string, string, error) {
 	owner := GetPropertyGithubOwner(ctx)
 	project := GetPropertyGithubProject(ctx)
@@ -57,41 +137,57 @@ func (g *Github) OwnerProject(ctx context.Context) (string, string, error) {
 		return owner, project, nil
 	}
 
-	if g.Remote() == nil {
-		return owner, project, fmt.Errorf("unable to dtermine Github project and owner from git remote configuration: unable to load git remote client")
+	host, err := RemoteHost(GetPropertyGithubBaseURL(ctx))
+	if err != nil {
+		return owner, project, err
 	}
 
-	cfg := g.Remote().Config()
-	if cfg == nil {
-		return owner, project, fmt.Errorf("unable to determine Github project and owner from git remote configuration: no remote configuration found")
-	}
+	return Forge.OwnerProject(g.Remote(), host, owner, project)
+}
 
-	urls := cfg.URLs
-	if len(urls) == 0 {
-		return owner, project, fmt.Errorf("unable to determine Github project and owner from git remote configuration: no remote URLs found")
+// markReadyMutation is the GraphQL mutation that marks a draft pull request as
+// ready for review.
+const markReadyMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+    pullRequest { isDraft }
+  }
+}`
+
+// graphQLURL returns the URL of the GraphQL API relative to the base URL of the
+// REST API. On github.com and some Github Enterprise installations, it sits
+// beside the REST API, e.g., https://api.github.com/graphql. Others serve the
+// REST API from /api/v3/ and GraphQL from /api/graphql.
+func graphQLURL(baseURL *url.URL) string {
+	if strings.HasSuffix(baseURL.Path, "/v3/") {
+		return "../graphql"
 	}
+	return "graphql"
+}
 
-	url := urls[0]
-	for _, prefix := range githubPrefixes {
-		if !strings.HasPrefix(url, prefix) {
-			continue
-		}
-
-		urlPath := url[len(prefix):]
-		urlPath = strings.TrimSuffix(urlPath, ".git")
+// MarkPullRequestReady marks the draft pull request with the given node ID as
+// ready for review, which Github requires before a draft may be merged. The
+// REST API cannot do this, so the GraphQL API is used.
+func (g *Github) MarkPullRequestReady(ctx context.Context, nodeID string) error {
+	req, err := g.gh.NewRequest(http.MethodPost, graphQLURL(g.gh.BaseURL), map[string]any{
+		"query":     markReadyMutation,
+		"variables": map[string]any{"id": nodeID},
+	})
+	if err != nil {
+		return format.WrapErr(err, "unable to build Github GraphQL request")
+	}
 
-		parts := strings.Split(urlPath, "/")
-		if len(parts) == 2 {
-			if owner == "" {
-				owner = parts[0]
-			}
-			if project == "" {
-				project = parts[1]
-			}
+	var res struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := g.gh.Do(ctx, req, &res); err != nil {
+		return format.WrapErr(err, "unable to mark pull request ready for review")
+	}
 
-			return owner, project, nil
-		}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("unable to mark pull request ready for review: %s", res.Errors[0].Message)
 	}
 
-	return owner, project, fmt.Errorf("unable to determine Github project and owner from git remote configuration: remote URL %q does not look like a github URL", url)
+	return nil
 }
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestRemoteHost(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                                  "github.com",
+		"https://ghe.example.com/api/v3/":   "ghe.example.com",
+		"https://api.octocorp.ghe.com/":     "octocorp.ghe.com",
+		"http://127.0.0.1:8080/api/v3":      "127.0.0.1",
+		"https://GHE.Example.com:8443/api/": "GHE.Example.com",
+	}
+
+	for baseURL, expect := range tests {
+		host, err := RemoteHost(baseURL)
+		require.NoError(t, err, baseURL)
+		assert.Equal(t, expect, host, baseURL)
+	}
+
+	_, err := RemoteHost("/api/v3")
+	assert.EqualError(t, err, `Github API URL "/api/v3" has no host`)
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][3]string{
+		"git@github.com:zostay/zedpm.git":              {"github.com", "zostay", "zedpm"},
+		"ssh://git@github.com/zostay/zedpm.git":        {"github.com", "zostay", "zedpm"},
+		"https://github.com/zostay/zedpm":              {"github.com", "zostay", "zedpm"},
+		"https://github.com/zostay/zedpm.git":          {"github.com", "zostay", "zedpm"},
+		"git@ghe.example.com:tools/zedpm.git":          {"ghe.example.com", "tools", "zedpm"},
+		"ssh://git@ghe.example.com:7999/tools/zedpm/":  {"ghe.example.com", "tools", "zedpm"},
+		"https://user@ghe.example.com/tools/zedpm.git": {"ghe.example.com", "tools", "zedpm"},
+	}
+
+	for remoteURL, expect := range tests {
+		host, owner, project, err := ParseRemoteURL(remoteURL)
+		require.NoError(t, err, remoteURL)
+		assert.Equal(t, expect, [3]string{host, owner, project}, remoteURL)
+	}
+
+	for _, remoteURL := range []string{
+		"/srv/git/zedpm.git",
+		"https://github.com/zedpm",
+		"https://gitlab.example.com/group/sub/zedpm.git",
+	} {
+		_, _, _, err := ParseRemoteURL(remoteURL)
+		assert.Error(t, err, remoteURL)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"https://api.github.com/":         "https://api.github.com/graphql",
+		"https://ghe.example.com/api/v3/": "https://ghe.example.com/api/graphql",
+		"https://api.octocorp.ghe.com/":   "https://api.octocorp.ghe.com/graphql",
+	}
+
+	for baseURL, expect := range tests {
+		u, err := url.Parse(baseURL)
+		require.NoError(t, err)
+		gql, err := u.Parse(graphQLURL(u))
+		require.NoError(t, err)
+		assert.Equal(t, expect, gql.String(), baseURL)
+	}
+}
+
+func TestGetPropertyGithubUploadURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		baseURL, uploadURL, expect string
+	}{
+		{"", "", ""},
+		{"https://ghe.example.com/api/v3/", "", "https://ghe.example.com/api/uploads/"},
+		{"http://127.0.0.1:8080/api/v3", "", "http://127.0.0.1:8080/api/uploads/"},
+		{"https://api.octocorp.ghe.com/", "", "https://uploads.octocorp.ghe.com/"},
+		{"https://ghe.example.com/api/v3/", "https://ghe.example.com/api/uploads/", "https://ghe.example.com/api/uploads/"},
+	}
+
+	for _, test := range tests {
+		kv := storage.New()
+		if test.baseURL != "" {
+			kv.Set(PropertyGithubBaseURL, test.baseURL)
+		}
+		if test.uploadURL != "" {
+			kv.Set(PropertyGithubUploadURL, test.uploadURL)
+		}
+		ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+
+		uploadURL := GetPropertyGithubUploadURL(ctx)
+		assert.Equal(t, test.expect, uploadURL, test.baseURL)
+
+		if test.baseURL == "" {
+			continue
+		}
+
+		// the client must upload to the same URL, not one with another
+		// api/uploads/ added to the end
+		gh, err := newEnterpriseClient(test.baseURL, uploadURL, nil)
+		require.NoError(t, err, test.baseURL)
+		assert.Equal(t, test.expect, gh.UploadURL.String(), test.baseURL)
+	}
+}
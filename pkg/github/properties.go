@@ -2,9 +2,13 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/zostay/zedpm/format"
 	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/storage"
 	"github.com/zostay/zedpm/plugin"
 )
 
@@ -12,8 +16,29 @@ const (
 	PropertyGithubReleaseName = "github.release.name"
 	PropertyGithubOwner       = "github.owner"
 	PropertyGithubProject     = "github.project"
+	PropertyGithubBaseURL     = "github.baseURL"
+	PropertyGithubUploadURL   = "github.uploadURL"
+
+	PropertyGithubMergeMethod       = "github.merge.method"
+	PropertyGithubMergeTitle        = "github.merge.title"
+	PropertyGithubMergeMessage      = "github.merge.message"
+	PropertyGithubMergeDeleteBranch = "github.merge.deleteBranch"
+
+	PropertyGithubPullRequestLabels    = "github.pullRequest.labels"
+	PropertyGithubPullRequestAssignees = "github.pullRequest.assignees"
+	PropertyGithubPullRequestReviewers = "github.pullRequest.reviewers"
+	PropertyGithubPullRequestDraft     = "github.pullRequest.draft"
 )
 
+// Merge methods that may be set in github.merge.method.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+const defaultMergeMessage = "Merging release branch."
+
 const defaultReleaseNamePrefix = "Release v"
 
 func GetPropertyGithubReleaseName(ctx context.Context) (string, error) {
@@ -36,3 +61,153 @@ func GetPropertyGithubOwner(ctx context.Context) string {
 func GetPropertyGithubProject(ctx context.Context) string {
 	return plugin.GetString(ctx, PropertyGithubProject)
 }
+
+// GetPropertyGithubBaseURL returns the URL of the Github API. This is empty
+// unless github.baseURL is set, which means api.github.com is used.
+func GetPropertyGithubBaseURL(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGithubBaseURL)
+}
+
+// GetPropertyGithubUploadURL returns the URL used to upload release assets to
+// Github. If github.uploadURL is not set, it is the api/uploads/ path on the
+// host of github.baseURL, e.g., "https://ghe.example.com/api/uploads/" for a
+// base URL of "https://ghe.example.com/api/v3/". When the host of the base URL
+// starts with "api.", uploads go to the root of the matching "uploads." host
+// instead, e.g., "https://uploads.octocorp.ghe.com/" for a base URL of
+// "https://api.octocorp.ghe.com/". It is empty if neither is set, which means
+// uploads.github.com is used.
+func GetPropertyGithubUploadURL(ctx context.Context) string {
+	if plugin.IsSet(ctx, PropertyGithubUploadURL) {
+		return plugin.GetString(ctx, PropertyGithubUploadURL)
+	}
+
+	baseURL := GetPropertyGithubBaseURL(ctx)
+	u, err := url.Parse(baseURL)
+	if baseURL == "" || err != nil || u.Host == "" {
+		return baseURL
+	}
+
+	if strings.HasPrefix(u.Host, "api.") {
+		upload := &url.URL{Scheme: u.Scheme, Host: "uploads." + strings.TrimPrefix(u.Host, "api."), Path: "/"}
+		return upload.String()
+	}
+
+	upload := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/api/uploads/"}
+	return upload.String()
+}
+
+// GetPropertyGithubMergeMethod returns the method used to merge the release
+// pull request, which is one of "merge", "squash", or "rebase". It defaults to
+// "merge".
+func GetPropertyGithubMergeMethod(ctx context.Context) (string, error) {
+	method := plugin.GetString(ctx, PropertyGithubMergeMethod)
+	switch method {
+	case "":
+		return MergeMethodMerge, nil
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+		return method, nil
+	}
+
+	return "", fmt.Errorf("%s must be one of %q, %q, or %q, not %q",
+		PropertyGithubMergeMethod, MergeMethodMerge, MergeMethodSquash, MergeMethodRebase, method)
+}
+
+// GetPropertyGithubMergeTitle returns the title of the commit made when
+// merging the release pull request. It is a Go template executed with the
+// properties, e.g., "Release v{{ .release.version }}". When empty, Github
+// picks the title.
+func GetPropertyGithubMergeTitle(ctx context.Context) (string, error) {
+	return executeTemplate(ctx, PropertyGithubMergeTitle, plugin.GetString(ctx, PropertyGithubMergeTitle))
+}
+
+// GetPropertyGithubMergeMessage returns the message of the commit made when
+// merging the release pull request. It is a Go template executed with the
+// properties, like github.merge.title, and defaults to "Merging release
+// branch."
+func GetPropertyGithubMergeMessage(ctx context.Context) (string, error) {
+	text := defaultMergeMessage
+	if plugin.IsSet(ctx, PropertyGithubMergeMessage) {
+		text = plugin.GetString(ctx, PropertyGithubMergeMessage)
+	}
+	return executeTemplate(ctx, PropertyGithubMergeMessage, text)
+}
+
+// executeTemplate executes the template text named by key with the current
+// properties.
+func executeTemplate(ctx context.Context, key, text string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	formatter, err := goals.TemplateOutputFormatter(key, text)
+	if err != nil {
+		return "", err
+	}
+
+	// copy the properties so the template sees them nested, e.g.,
+	// {{ .release.version }}
+	values := storage.New()
+	plugin.AtomicProperties(ctx, func(props storage.KV) {
+		values.UpdateStrings(props.AllSettingsStrings())
+	})
+
+	var out strings.Builder
+	if err := formatter(&out, values); err != nil {
+		return "", format.WrapErr(err, "unable to execute template %s", key)
+	}
+
+	return out.String(), nil
+}
+
+// GetPropertyGithubMergeDeleteBranch returns true if the release branch should
+// be deleted after the release pull request is merged.
+func GetPropertyGithubMergeDeleteBranch(ctx context.Context) bool {
+	return plugin.GetBool(ctx, PropertyGithubMergeDeleteBranch)
+}
+
+// GetPropertyGithubPullRequestLabels returns the labels to add to the release
+// pull request, read from the comma-separated list in
+// github.pullRequest.labels.
+func GetPropertyGithubPullRequestLabels(ctx context.Context) []string {
+	return getList(ctx, PropertyGithubPullRequestLabels)
+}
+
+// GetPropertyGithubPullRequestAssignees returns the logins of the users to
+// assign to the release pull request, read from the comma-separated list in
+// github.pullRequest.assignees.
+func GetPropertyGithubPullRequestAssignees(ctx context.Context) []string {
+	return getList(ctx, PropertyGithubPullRequestAssignees)
+}
+
+// GetPropertyGithubPullRequestReviewers returns the users and teams to request
+// reviews of the release pull request from, read from the comma-separated list
+// in github.pullRequest.reviewers. Teams are named as "org/team-slug" and are
+// returned separately from the user logins.
+func GetPropertyGithubPullRequestReviewers(ctx context.Context) (users, teams []string) {
+	for _, reviewer := range getList(ctx, PropertyGithubPullRequestReviewers) {
+		if _, team, isTeam := strings.Cut(reviewer, "/"); isTeam {
+			teams = append(teams, team)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	return users, teams
+}
+
+// GetPropertyGithubPullRequestDraft returns true if the release pull request
+// should be opened as a draft.
+func GetPropertyGithubPullRequestDraft(ctx context.Context) bool {
+	return plugin.GetBool(ctx, PropertyGithubPullRequestDraft)
+}
+
+// getList returns the trimmed, non-empty values in the comma-separated list
+// stored in key.
+func getList(ctx context.Context, key string) []string {
+	var values []string
+	for _, value := range strings.Split(plugin.GetString(ctx, key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,276 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// Client is a minimal client for the parts of the GitLab REST API (v4) used by
+// zedpm.
+type Client struct {
+	baseURL *url.URL
+	token   string
+	hc      *http.Client
+}
+
+// NewClient returns a client for the GitLab instance at the given base URL,
+// e.g., https://gitlab.com. The /api/v4 path is added unless the base URL
+// already ends with it. The token is sent as a personal, project, or group
+// access token. If hc is nil, http.DefaultClient is used.
+func NewClient(baseURL, token string, hc *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to parse GitLab URL %q", baseURL)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("GitLab URL %q has no host", baseURL)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api/v4") {
+		u.Path += "/api/v4"
+	}
+	u.Path += "/"
+
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	return &Client{u, token, hc}, nil
+}
+
+// ErrorResponse is the error returned when the GitLab API responds with an
+// error status.
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the status and message of the response.
+func (e *ErrorResponse) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitLab API error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("GitLab API error: %d %s", e.StatusCode, e.Message)
+}
+
+// do sends a request to the API endpoint at the given path, relative to the API
+// base URL. The in value, if not nil, is sent as JSON and the JSON response is
+// decoded into out, if not nil.
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	query url.Values,
+	in any,
+	out any,
+) error {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return format.WrapErr(err, "unable to encode GitLab request")
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return format.WrapErr(err, "unable to create GitLab request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return format.WrapErr(err, "GitLab request failed")
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode >= 300 {
+		var msg struct {
+			Message any    `json:"message"`
+			Error   string `json:"error"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&msg)
+
+		errRes := &ErrorResponse{StatusCode: res.StatusCode, Message: msg.Error}
+		if msg.Message != nil {
+			errRes.Message = fmt.Sprint(msg.Message)
+		}
+		return errRes
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return format.WrapErr(err, "unable to decode GitLab response")
+	}
+
+	return nil
+}
+
+// projectPath returns the API path for the named project, e.g., "group/project".
+func projectPath(project string, rest ...string) string {
+	return strings.Join(append([]string{"projects", url.PathEscape(project)}, rest...), "/")
+}
+
+// Pipeline is a GitLab CI/CD pipeline.
+type Pipeline struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Ref    string `json:"ref"`
+	WebURL string `json:"web_url"`
+}
+
+// Pipeline statuses that mean the pipeline has finished.
+const (
+	PipelineSuccess  = "success"
+	PipelineFailed   = "failed"
+	PipelineCanceled = "canceled"
+	PipelineSkipped  = "skipped"
+)
+
+// MergeRequest is a GitLab merge request.
+type MergeRequest struct {
+	IID          int       `json:"iid"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	State        string    `json:"state"`
+	SourceBranch string    `json:"source_branch"`
+	TargetBranch string    `json:"target_branch"`
+	WebURL       string    `json:"web_url"`
+	HeadPipeline *Pipeline `json:"head_pipeline"`
+}
+
+// MergeRequestMerged is the state of a merge request that has been merged.
+const MergeRequestMerged = "merged"
+
+// NewMergeRequest describes the merge request to create.
+type NewMergeRequest struct {
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	Title              string `json:"title"`
+	Description        string `json:"description,omitempty"`
+	RemoveSourceBranch bool   `json:"remove_source_branch,omitempty"`
+}
+
+// CreateMergeRequest creates a merge request in the project.
+func (c *Client) CreateMergeRequest(
+	ctx context.Context,
+	project string,
+	mr *NewMergeRequest,
+) (*MergeRequest, error) {
+	var out MergeRequest
+	err := c.do(ctx, http.MethodPost, projectPath(project, "merge_requests"), nil, mr, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FindMergeRequest returns the open merge request of the project with the given
+// source branch or nil if there is none.
+func (c *Client) FindMergeRequest(
+	ctx context.Context,
+	project string,
+	sourceBranch string,
+) (*MergeRequest, error) {
+	var out []*MergeRequest
+	query := url.Values{
+		"source_branch": {sourceBranch},
+		"state":         {"opened"},
+	}
+	err := c.do(ctx, http.MethodGet, projectPath(project, "merge_requests"), query, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, nil
+	}
+
+	// the list endpoint does not include the pipeline, so fetch it individually
+	return c.GetMergeRequest(ctx, project, out[0].IID)
+}
+
+// GetMergeRequest returns the merge request with the given IID.
+func (c *Client) GetMergeRequest(
+	ctx context.Context,
+	project string,
+	iid int,
+) (*MergeRequest, error) {
+	var out MergeRequest
+	path := projectPath(project, "merge_requests", strconv.Itoa(iid))
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AcceptMergeRequest merges the merge request with the given IID.
+func (c *Client) AcceptMergeRequest(
+	ctx context.Context,
+	project string,
+	iid int,
+	message string,
+) (*MergeRequest, error) {
+	var out MergeRequest
+	path := projectPath(project, "merge_requests", strconv.Itoa(iid), "merge")
+	in := map[string]any{}
+	if message != "" {
+		in["merge_commit_message"] = message
+	}
+	err := c.do(ctx, http.MethodPut, path, nil, in, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Release is a GitLab release.
+type Release struct {
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Ref         string     `json:"ref,omitempty"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+}
+
+// CreateRelease creates a release for the project. If the tag does not exist
+// yet, GitLab creates it from Ref.
+func (c *Client) CreateRelease(
+	ctx context.Context,
+	project string,
+	rel *Release,
+) (*Release, error) {
+	var out Release
+	err := c.do(ctx, http.MethodPost, projectPath(project, "releases"), nil, rel, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,3 @@
+// Package gitlab provides shared tooling to allow zedpm plugins to interact
+// with GitLab in a consistent way.
+package gitlab
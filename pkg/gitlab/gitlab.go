@@ -0,0 +1,91 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+)
+
+// Gitlab provides a client object for accessing the GitLab API.
+type Gitlab struct {
+	git.Git
+	gl *Client
+}
+
+func (g *Gitlab) Client() *Client {
+	return g.gl
+}
+
+// SetupGitlabClient opens the git repository and configures the GitLab client
+// for the instance at gitlab.baseURL with the token in GITLAB_TOKEN.
+func (g *Gitlab) SetupGitlabClient(ctx context.Context) error {
+	err := g.Git.SetupGitRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GITLAB_TOKEN environment variable is missing")
+	}
+
+	g.gl, err = NewClient(GetPropertyGitlabBaseURL(ctx), token, nil)
+	return err
+}
+
+// Forge describes GitLab to the shared git remote URL handling. The owner of a
+// GitLab repository is its full namespace, everything before the last slash.
+var Forge = &git.Forge{
+	Name: "GitLab",
+	SplitPath: func(repoPath string) (string, string, bool) {
+		slash := strings.LastIndex(repoPath, "/")
+		if slash < 0 {
+			return "", "", false
+		}
+		return repoPath[:slash], repoPath[slash+1:], true
+	},
+}
+
+// ParseRemoteURL splits a git remote URL into the host, owner, and project of
+// a GitLab repository. The owner is the full namespace of the project, which
+// may include subgroups, e.g., "group/subgroup". It accepts URLs like
+// https://host/group/project.git, ssh://git@host:port/group/project.git, and
+// the scp-like git@host:group/project.git.
+func ParseRemoteURL(remoteURL string) (host, owner, project string, err error) {
+	return Forge.ParseRemoteURL(remoteURL)
+}
+
+// OwnerProject returns the owner (namespace) and project of the GitLab
+// repository. These are taken from gitlab.owner and gitlab.project or, when
+// those are not set, from the first URL of the git remote, which must be on the
+// host of gitlab.baseURL.
+func (g *Gitlab) OwnerProject(ctx context.Context) (string, string, error) {
+	owner := GetPropertyGitlabOwner(ctx)
+	project := GetPropertyGitlabProject(ctx)
+
+	if owner != "" && project != "" {
+		return owner, project, nil
+	}
+
+	baseURL, err := url.Parse(GetPropertyGitlabBaseURL(ctx))
+	if err != nil {
+		return owner, project, format.WrapErr(err, "unable to parse %q", PropertyGitlabBaseURL)
+	}
+
+	return Forge.OwnerProject(g.Remote(), baseURL.Hostname(), owner, project)
+}
+
+// ProjectID returns the path of the project, e.g., "group/project", which is
+// used to identify the project to the GitLab API.
+func (g *Gitlab) ProjectID(ctx context.Context) (string, error) {
+	owner, project, err := g.OwnerProject(ctx)
+	if err != nil {
+		return "", err
+	}
+	return owner + "/" + project, nil
+}
@@ -0,0 +1,68 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][3]string{
+		"git@gitlab.com:zostay/zedpm.git":                {"gitlab.com", "zostay", "zedpm"},
+		"https://gitlab.com/zostay/zedpm":                {"gitlab.com", "zostay", "zedpm"},
+		"ssh://git@git.example.com:2222/a/b/c/zedpm.git": {"git.example.com", "a/b/c", "zedpm"},
+		"https://git.example.com/group/sub/zedpm.git/":   {"git.example.com", "group/sub", "zedpm"},
+	}
+
+	for remoteURL, expect := range tests {
+		host, owner, project, err := ParseRemoteURL(remoteURL)
+		require.NoError(t, err, remoteURL)
+		assert.Equal(t, expect, [3]string{host, owner, project}, remoteURL)
+	}
+
+	for _, remoteURL := range []string{
+		"/srv/git/zedpm.git",
+		"https://gitlab.com/zedpm",
+		"git@gitlab.com:zostay/",
+	} {
+		_, _, _, err := ParseRemoteURL(remoteURL)
+		assert.Error(t, err, remoteURL)
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotToken = r.URL.EscapedPath(), r.Header.Get("PRIVATE-TOKEN")
+		if r.URL.Query().Get("state") == "closed" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"403 Forbidden"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL+"/gitlab/", "secret", nil)
+	require.NoError(t, err)
+
+	mr, err := c.FindMergeRequest(context.Background(), "group/sub/zedpm", "release-v1.0.0")
+	require.NoError(t, err)
+	assert.Nil(t, mr)
+	assert.Equal(t, "/gitlab/api/v4/projects/group%2Fsub%2Fzedpm/merge_requests", gotPath)
+	assert.Equal(t, "secret", gotToken)
+
+	err = c.do(context.Background(), http.MethodGet, "projects", map[string][]string{"state": {"closed"}}, nil, nil)
+	assert.EqualError(t, err, "GitLab API error: 403 403 Forbidden")
+
+	_, err = NewClient("gitlab.example.com", "", nil)
+	assert.EqualError(t, err, `GitLab URL "gitlab.example.com" has no host`)
+}
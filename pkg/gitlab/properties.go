@@ -0,0 +1,74 @@
+package gitlab
+
+import (
+	"context"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+const (
+	PropertyGitlabBaseURL          = "gitlab.baseURL"
+	PropertyGitlabOwner            = "gitlab.owner"
+	PropertyGitlabProject          = "gitlab.project"
+	PropertyGitlabReleaseName      = "gitlab.release.name"
+	PropertyGitlabPipelineTimeout  = "gitlab.pipeline.timeout"
+	PropertyGitlabPipelineInterval = "gitlab.pipeline.interval"
+)
+
+const (
+	defaultBaseURL           = "https://gitlab.com"
+	defaultReleaseNamePrefix = "Release v"
+	defaultPipelineTimeout   = 15 * time.Minute
+	defaultPipelineInterval  = 30 * time.Second
+)
+
+// GetPropertyGitlabBaseURL returns the URL of the GitLab instance, which
+// defaults to https://gitlab.com.
+func GetPropertyGitlabBaseURL(ctx context.Context) string {
+	if baseURL := plugin.GetString(ctx, PropertyGitlabBaseURL); baseURL != "" {
+		return baseURL
+	}
+	return defaultBaseURL
+}
+
+func GetPropertyGitlabOwner(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGitlabOwner)
+}
+
+func GetPropertyGitlabProject(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyGitlabProject)
+}
+
+func GetPropertyGitlabReleaseName(ctx context.Context) (string, error) {
+	if plugin.IsSet(ctx, PropertyGitlabReleaseName) {
+		return plugin.GetString(ctx, PropertyGitlabReleaseName), nil
+	}
+
+	version, err := goals.GetPropertyReleaseVersion(ctx)
+	if err != nil {
+		return "", format.WrapErr(err, "unable to get or create a value for %q", PropertyGitlabReleaseName)
+	}
+
+	return defaultReleaseNamePrefix + version, nil
+}
+
+// GetPropertyGitlabPipelineTimeout returns how long to wait for the pipeline of
+// the merge request to succeed, which defaults to 15 minutes.
+func GetPropertyGitlabPipelineTimeout(ctx context.Context) time.Duration {
+	if timeout := plugin.GetDuration(ctx, PropertyGitlabPipelineTimeout); timeout > 0 {
+		return timeout
+	}
+	return defaultPipelineTimeout
+}
+
+// GetPropertyGitlabPipelineInterval returns how long to wait between checks of
+// the pipeline status, which defaults to 30 seconds.
+func GetPropertyGitlabPipelineInterval(ctx context.Context) time.Duration {
+	if interval := plugin.GetDuration(ctx, PropertyGitlabPipelineInterval); interval > 0 {
+		return interval
+	}
+	return defaultPipelineInterval
+}
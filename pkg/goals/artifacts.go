@@ -0,0 +1,96 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// AddReleaseArtifacts registers files built by a task to be attached to the
+// release by adding them to release.artifacts. Relative file names are made
+// absolute, so they are not affected by module.dir.
+func AddReleaseArtifacts(ctx context.Context, files ...string) error {
+	abs := make([]string, len(files))
+	for i, file := range files {
+		var err error
+		abs[i], err = filepath.Abs(file)
+		if err != nil {
+			return format.WrapErr(err, "unable to find release artifact %q", file)
+		}
+	}
+
+	plugin.AtomicProperties(ctx, func(kv storage.KV) {
+		artifacts := kv.GetString(PropertyReleaseArtifacts)
+		if artifacts != "" {
+			artifacts += ","
+		}
+		kv.Set(PropertyReleaseArtifacts, artifacts+strings.Join(abs, ","))
+	})
+
+	return nil
+}
+
+// GetPropertyReleaseArtifacts returns the files to attach to the release. These
+// are found by expanding the comma-separated glob patterns of
+// release.artifacts, which are relative to the module directory (see
+// ModulePath). Directories are skipped and each file is returned once, in the
+// order the patterns name them.
+//
+// It fails if a pattern is malformed or matches no files, since a missing
+// artifact usually means the build did not run. It also fails if two files
+// have the same base name, since releases name each attached file by its base
+// name and the second would collide with the first.
+func GetPropertyReleaseArtifacts(ctx context.Context) ([]string, error) {
+	files := []string{}
+	seen := map[string]struct{}{}
+	names := map[string]string{}
+	for _, pattern := range strings.Split(plugin.GetString(ctx, PropertyReleaseArtifacts), ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		matches, err := filepath.Glob(ModulePath(ctx, pattern))
+		if err != nil {
+			return nil, format.WrapErr(err, "bad %s pattern %q", PropertyReleaseArtifacts, pattern)
+		}
+
+		found := false
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, format.WrapErr(err, "unable to read release artifact %q", match)
+			}
+
+			if info.IsDir() {
+				continue
+			}
+
+			found = true
+			if _, dup := seen[match]; dup {
+				continue
+			}
+
+			name := filepath.Base(match)
+			if other, dup := names[name]; dup {
+				return nil, fmt.Errorf("release artifacts %q and %q would both be attached as %q", other, match, name)
+			}
+
+			seen[match] = struct{}{}
+			names[name] = match
+			files = append(files, match)
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%s pattern %q matches no files", PropertyReleaseArtifacts, pattern)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,60 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestGetPropertyReleaseArtifacts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "dist", "sub.zip"), 0o755))
+	for _, name := range []string{"a.zip", "b.zip", "checksums.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "dist", name), []byte(name), 0o644))
+	}
+
+	artifacts := func(patterns string) ([]string, error) {
+		kv := storage.New()
+		kv.Set(PropertyModuleDir, dir)
+		kv.Set(PropertyReleaseArtifacts, patterns)
+		ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+		return GetPropertyReleaseArtifacts(ctx)
+	}
+
+	files, err := artifacts("")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	files, err = artifacts("dist/checksums.txt, dist/*.zip, dist/a.zip")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "dist", "checksums.txt"),
+		filepath.Join(dir, "dist", "a.zip"),
+		filepath.Join(dir, "dist", "b.zip"),
+	}, files)
+
+	kv := storage.New()
+	kv.Set(PropertyModuleDir, "elsewhere")
+	kv.Set(PropertyReleaseArtifacts, "dist/nope.zip")
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+	require.NoError(t, AddReleaseArtifacts(ctx, filepath.Join(dir, "dist", "b.zip")))
+	assert.Equal(t, "dist/nope.zip,"+filepath.Join(dir, "dist", "b.zip"), plugin.GetString(ctx, PropertyReleaseArtifacts))
+
+	_, err = artifacts("dist/*.zip,dist/*.tar.gz")
+	assert.EqualError(t, err, `release.artifacts pattern "dist/*.tar.gz" matches no files`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.zip"), []byte("a.zip"), 0o644))
+	_, err = artifacts("dist/*.zip,a.zip")
+	assert.EqualError(t, err, fmt.Sprintf("release artifacts %q and %q would both be attached as %q",
+		filepath.Join(dir, "dist", "a.zip"), filepath.Join(dir, "a.zip"), "a.zip"))
+}
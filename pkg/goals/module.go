@@ -0,0 +1,87 @@
+package goals
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// GetPropertyModuleDir returns the directory of the go module the goal is
+// scoped to, relative to the project root and separated with slashes. It
+// returns "." if module.dir is not set, meaning the module at the root.
+func GetPropertyModuleDir(ctx context.Context) string {
+	dir := plugin.GetString(ctx, PropertyModuleDir)
+	if dir == "" {
+		return "."
+	}
+	return path.Clean(filepath.ToSlash(dir))
+}
+
+// ReleaseTagPrefix returns the prefix of the release tags of the module the
+// goal is scoped to. This is PropertyReleaseTagPrefix for the root module. For
+// a module in a subdirectory, the prefix begins with the module directory, as
+// go expects, e.g., "api/v".
+func ReleaseTagPrefix(ctx context.Context) string {
+	dir := GetPropertyModuleDir(ctx)
+	if dir == "." {
+		return PropertyReleaseTagPrefix
+	}
+	return dir + "/" + PropertyReleaseTagPrefix
+}
+
+// ModulePath returns the named file relative to the directory of the module
+// the goal is scoped to. Absolute file names are returned as-is.
+func ModulePath(ctx context.Context, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(filepath.FromSlash(GetPropertyModuleDir(ctx)), name)
+}
+
+// FindModules returns the directories containing a go.mod file under the given
+// root, relative to the root and separated with slashes, with "." naming the
+// root itself. The directories that the go command ignores are skipped: those
+// named vendor or testdata and those whose names start with "." or "_".
+func FindModules(root string) ([]string, error) {
+	dirs := []string{}
+	err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		base := d.Name()
+		if name != root && (base == "vendor" || base == "testdata" ||
+			strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+			return filepath.SkipDir
+		}
+
+		if _, err := os.Stat(filepath.Join(name, "go.mod")); err != nil {
+			return nil //nolint:nilerr // not a module, so keep looking
+		}
+
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+
+		dirs = append(dirs, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to search for go modules")
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
@@ -0,0 +1,54 @@
+package goals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func moduleContext(dir string) context.Context {
+	kv := storage.New()
+	if dir != "" {
+		kv.Set(PropertyModuleDir, dir)
+	}
+	return plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+}
+
+func TestModuleProperties(t *testing.T) {
+	t.Parallel()
+
+	ctx := moduleContext("")
+	assert.Equal(t, ".", GetPropertyModuleDir(ctx))
+	assert.Equal(t, "v", ReleaseTagPrefix(ctx))
+	assert.Equal(t, "Changes.md", ModulePath(ctx, "Changes.md"))
+
+	ctx = moduleContext("tools/api/")
+	assert.Equal(t, "tools/api", GetPropertyModuleDir(ctx))
+	assert.Equal(t, "tools/api/v", ReleaseTagPrefix(ctx))
+	assert.Equal(t, filepath.Join("tools", "api", "Changes.md"), ModulePath(ctx, "Changes.md"))
+
+	abs := filepath.Join(t.TempDir(), "Changes.md")
+	assert.Equal(t, abs, ModulePath(ctx, abs))
+}
+
+func TestFindModules(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, dir := range []string{".", "api", "tools/cli", "vendor/x", "testdata/y", ".hidden", "_skip"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "go.mod"), []byte("module x\n"), 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs"), 0o755))
+
+	modules, err := FindModules(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "api", "tools/cli"}, modules)
+}
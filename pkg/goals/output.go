@@ -2,9 +2,16 @@ package goals
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 
 	"github.com/zostay/zedpm/pkg/storage"
@@ -13,25 +20,68 @@ import (
 // OutputFormatter is a function that can output a storage.KV to the given io.Writer.
 type OutputFormatter func(io.Writer, storage.KV) error
 
-// OutputFormats defines the available output formats.
+// OutputFormats defines the available output formats. In addition to these,
+// the info.outputFormat property may be set to "template:<file>" to format the
+// output with the Go template in the named file or to "template=<template>" to
+// format the output with the given Go template.
 var OutputFormats = map[string]OutputFormatter{
-	"properties": WriteOutProperties,
-	"yaml":       WriteOutYaml,
+	"properties":    WriteOutProperties,
+	"yaml":          WriteOutYaml,
+	"json":          WriteOutJSON,
+	"toml":          WriteOutTOML,
+	"env":           WriteOutEnv,
+	"github-output": WriteOutGithubOutput,
 }
 
+const (
+	// OutputFormatTemplateFile is the prefix of an info.outputFormat naming a
+	// file containing a Go template.
+	OutputFormatTemplateFile = "template:"
+
+	// OutputFormatTemplate is the prefix of an info.outputFormat containing a
+	// Go template.
+	OutputFormatTemplate = "template="
+)
+
 // DefaultInfoOutputFormatter is the default output format.
 var DefaultInfoOutputFormatter = WriteOutProperties
 
-// InfoOutputFormatter tries to determine which output formatter to use based
-// upon the info.outputFormat property. If the property is not set or set to an
-// invalid value, this will return DefaultInfoOutputFormatter.
-func InfoOutputFormatter(ctx context.Context) OutputFormatter {
+// InfoOutputFormatter determines which output formatter to use based upon the
+// info.outputFormat property. If the property is not set, this will return
+// DefaultInfoOutputFormatter. It returns an error if the format is not known or
+// the template cannot be loaded.
+func InfoOutputFormatter(ctx context.Context) (OutputFormatter, error) {
 	format := GetPropertyInfoOutputFormat(ctx)
-	formatter := OutputFormats[format]
-	if formatter != nil {
-		return formatter
+	return OutputFormatterFor(format)
+}
+
+// OutputFormatterFor returns the output formatter for the named format. See
+// OutputFormats for the accepted names.
+func OutputFormatterFor(format string) (OutputFormatter, error) {
+	switch {
+	case strings.HasPrefix(format, OutputFormatTemplateFile):
+		fn := strings.TrimPrefix(format, OutputFormatTemplateFile)
+		text, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read output template %s: %w", fn, err)
+		}
+		return TemplateOutputFormatter(fn, string(text))
+	case strings.HasPrefix(format, OutputFormatTemplate):
+		return TemplateOutputFormatter("inline", strings.TrimPrefix(format, OutputFormatTemplate))
 	}
-	return DefaultInfoOutputFormatter
+
+	if formatter := OutputFormats[format]; formatter != nil {
+		return formatter, nil
+	}
+
+	names := make([]string, 0, len(OutputFormats)+2)
+	for name := range OutputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	names = append(names, OutputFormatTemplateFile+"<file>", OutputFormatTemplate+"<template>")
+
+	return nil, fmt.Errorf("unknown output format %q, expected one of: %s", format, strings.Join(names, ", "))
 }
 
 // WriteOutProperties outputs the given storage values as a properties list.
@@ -50,3 +100,110 @@ func WriteOutYaml(w io.Writer, values storage.KV) error {
 	enc := yaml.NewEncoder(w)
 	return enc.Encode(values.AllSettings())
 }
+
+// WriteOutJSON outputs the given storage values in JSON format.
+func WriteOutJSON(w io.Writer, values storage.KV) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(values.AllSettings())
+}
+
+// WriteOutTOML outputs the given storage values in TOML format.
+func WriteOutTOML(w io.Writer, values storage.KV) error {
+	enc := toml.NewEncoder(w)
+	return enc.Encode(values.AllSettings())
+}
+
+// notEnvChars matches the characters that may not be used in an environment
+// variable name.
+var notEnvChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// EnvName mangles a property key into an environment variable name by
+// replacing every character that is not a letter, digit, or underscore with an
+// underscore and converting it to upper case. For example, "release.version"
+// becomes "RELEASE_VERSION".
+func EnvName(key string) string {
+	name := strings.ToUpper(notEnvChars.ReplaceAllString(key, "_"))
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// ShellQuote quotes the value for use in a POSIX shell.
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// sortedKeys returns the keys of the values in sorted order.
+func sortedKeys(values storage.KV) []string {
+	keys := values.AllKeys()
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteOutEnv outputs the given storage values as shell export statements
+// suitable for use with eval. See EnvName for how keys are named.
+func WriteOutEnv(w io.Writer, values storage.KV) error {
+	for _, key := range sortedKeys(values) {
+		_, err := fmt.Fprintf(w, "export %s=%s\n", EnvName(key), ShellQuote(values.GetString(key)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notOutputChars matches the characters that may not be used in the name of a
+// GitHub Actions step output.
+var notOutputChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// WriteOutGithubOutput outputs the given storage values in the format of the
+// file named by $GITHUB_OUTPUT in GitHub Actions. Characters in the key that
+// may not be used in an output name, such as ".", are replaced with
+// underscores. Multiline values are written using a heredoc-style delimiter.
+func WriteOutGithubOutput(w io.Writer, values storage.KV) error {
+	for _, key := range sortedKeys(values) {
+		name := notOutputChars.ReplaceAllString(key, "_")
+		value := values.GetString(key)
+
+		var err error
+		if strings.Contains(value, "\n") {
+			delim := "ZEDPM_EOF"
+			for strings.Contains(value, delim) {
+				delim += "_"
+			}
+			_, err = fmt.Fprintf(w, "%s<<%s\n%s\n%s\n", name, delim, strings.TrimSuffix(value, "\n"), delim)
+		} else {
+			_, err = fmt.Fprintf(w, "%s=%s\n", name, value)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TemplateOutputFormatter returns an OutputFormatter that executes the given Go
+// template. The template is executed with the nested map of values, so
+// {{ .release.version }} outputs the value of release.version. The get function
+// may also be used to look up any key, e.g., {{ get "release.version" }}.
+func TemplateOutputFormatter(name, text string) (OutputFormatter, error) {
+	// the get function is replaced on execution
+	funcs := template.FuncMap{"get": func(string) any { return nil }}
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse output template %s: %w", name, err)
+	}
+
+	return func(w io.Writer, values storage.KV) error {
+		t, err := tmpl.Clone()
+		if err != nil {
+			return err
+		}
+
+		t.Funcs(template.FuncMap{"get": values.Get})
+		return t.Execute(w, values.AllSettings())
+	}, nil
+}
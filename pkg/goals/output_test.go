@@ -0,0 +1,44 @@
+package goals
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+func TestOutputFormats(t *testing.T) {
+	values := storage.New()
+	values.Set("release.version", "1.2.0")
+	values.Set("release.description", " * It's new.\n * It's better.\n")
+
+	tests := []struct {
+		format, out string
+	}{
+		{"env", "export RELEASE_DESCRIPTION=' * It'\\''s new.\n * It'\\''s better.\n'\n" +
+			"export RELEASE_VERSION='1.2.0'\n"},
+		{"github-output", "release_description<<ZEDPM_EOF\n * It's new.\n * It's better.\nZEDPM_EOF\n" +
+			"release_version=1.2.0\n"},
+		{"json", "{\n  \"release\": {\n    \"description\": \" * It's new.\\n * It's better.\\n\",\n    \"version\": \"1.2.0\"\n  }\n}\n"},
+		{"toml", "[release]\n  description = \" * It's new.\\n * It's better.\\n\"\n  version = \"1.2.0\"\n"},
+		{`template=v{{ .release.version }} {{ get "release.version" }}`, "v1.2.0 1.2.0"},
+	}
+
+	for _, test := range tests {
+		formatter, err := OutputFormatterFor(test.format)
+		require.NoError(t, err, test.format)
+
+		w := &bytes.Buffer{}
+		require.NoError(t, formatter(w, values), test.format)
+		assert.Equal(t, test.out, w.String(), test.format)
+	}
+
+	_, err := OutputFormatterFor("xml")
+	assert.Error(t, err)
+
+	_, err = OutputFormatterFor("template:does-not-exist.tmpl")
+	assert.Error(t, err)
+}
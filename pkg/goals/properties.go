@@ -3,6 +3,7 @@ package goals
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/zostay/zedpm/format"
@@ -18,15 +19,28 @@ const (
 	PropertyReleaseVersion     = "release.version"
 	PropertyReleaseDate        = "release.date"
 	PropertyReleaseTag         = "release.tag"
+	PropertyReleaseBump        = "release.bump"
+	PropertyReleasePreRelease  = "release.prerelease"
+	PropertyReleaseArtifacts   = "release.artifacts"
 
 	PropertyLintPreRelease = "lint.prerelease"
 	PropertyLintRelease    = "lint.release"
 
+	PropertyModuleDir = "module.dir"
+
+	PropertyRequestTitle       = "request.title"
+	PropertyRequestDescription = "request.description"
+
 	PropertyInfoVersion      = "info.version"
 	PropertyInfoOutputFormat = "info.outputFormat"
 	PropertyInfoOutputAll    = "info.outputAll"
+	PropertyInfoQuery        = "info.query"
 
 	DefaultInfoOutputFormat = "properties"
+
+	// ReleaseVersionPattern is the pattern that release.version must match,
+	// which is a semantic version without the tag prefix.
+	ReleaseVersionPattern = `^\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`
 )
 
 // SetPropertyReleaseDescription sets the release.description property to the
@@ -57,6 +71,23 @@ func GetPropertyInfoOutputAll(ctx context.Context) bool {
 	return plugin.GetBool(ctx, PropertyInfoOutputAll)
 }
 
+// GetPropertyInfoQuery returns the list of comma-separated query patterns set in
+// info.query. It returns nil if no query is set.
+func GetPropertyInfoQuery(ctx context.Context) []string {
+	value := plugin.GetString(ctx, PropertyInfoQuery)
+	if value == "" {
+		return nil
+	}
+
+	patterns := []string{}
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
 // GetPropertyReleaseDescription returns the value of release.description.
 func GetPropertyReleaseDescription(ctx context.Context) string {
 	desc := plugin.GetString(ctx, PropertyReleaseDescription)
@@ -81,6 +112,45 @@ func GetPropertyReleaseVersion(ctx context.Context) (string, error) {
 	return "", fmt.Errorf("%q is not defined", PropertyReleaseVersion)
 }
 
+// GetPropertyReleaseBump gets the value of release.bump, which names the part
+// of the version to increment when computing the next release version.
+func GetPropertyReleaseBump(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyReleaseBump)
+}
+
+// IsPreRelease returns true if the given version has a pre-release part, such as
+// the "-rc.1" of "1.2.0-rc.1".
+func IsPreRelease(version string) bool {
+	version, _, _ = strings.Cut(strings.TrimPrefix(version, PropertyReleaseTagPrefix), "+")
+	return strings.Contains(version, "-")
+}
+
+// GetPropertyReleasePreRelease returns the value of release.prerelease. If it
+// is not set, the release is a pre-release if release.version has a
+// pre-release part.
+func GetPropertyReleasePreRelease(ctx context.Context) bool {
+	if plugin.IsSet(ctx, PropertyReleasePreRelease) {
+		return plugin.GetBool(ctx, PropertyReleasePreRelease)
+	}
+
+	version, err := GetPropertyReleaseVersion(ctx)
+	if err != nil {
+		return false
+	}
+
+	return IsPreRelease(version)
+}
+
+// RequirePropertyReleaseVersion declares that the current task requires
+// release.version to be set. This should be called while preparing the task.
+func RequirePropertyReleaseVersion(ctx context.Context) {
+	plugin.Require(ctx, plugin.Requirement{
+		Key:         PropertyReleaseVersion,
+		Description: "Release version",
+		Pattern:     ReleaseVersionPattern,
+	})
+}
+
 // SetPropertyReleaseDate sets the value of release.date.
 func SetPropertyReleaseDate(ctx context.Context, date time.Time) {
 	plugin.Set(ctx, PropertyReleaseDate, date)
@@ -136,5 +206,19 @@ func GetPropertyReleaseTag(ctx context.Context) (string, error) {
 		return "", format.WrapErr(err, "unable to get or create a value for %q", PropertyReleaseTag)
 	}
 
-	return PropertyReleaseTagPrefix + version, nil
+	return ReleaseTagPrefix(ctx) + version, nil
+}
+
+// GetPropertyRequestTitle returns the value of request.title, the title of the
+// pull or merge request to create. If it is empty, the request is usually
+// titled with the subject of the last commit.
+func GetPropertyRequestTitle(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyRequestTitle)
+}
+
+// GetPropertyRequestDescription returns the value of request.description, the
+// description of the pull or merge request to create. If it is empty, the body
+// of the last commit message is usually used instead.
+func GetPropertyRequestDescription(ctx context.Context) string {
+	return plugin.GetString(ctx, PropertyRequestDescription)
 }
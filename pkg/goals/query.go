@@ -0,0 +1,81 @@
+package goals
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+// isWildcard returns true if the query pattern contains wildcards.
+func isWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// matchQuery returns true if the key is selected by the query pattern. A
+// pattern selects the key with the same name and every key below it, so
+// "release" selects "release.version". In a pattern, "*" matches any part of a
+// single dot-separated segment of the key and "?" matches any single character
+// other than ".", so "git.*" selects "git.head" and "git.tag.name".
+func matchQuery(pattern, key string) bool {
+	keyParts := strings.Split(key, ".")
+	patternParts := strings.Split(pattern, ".")
+	if len(patternParts) > len(keyParts) {
+		return false
+	}
+
+	for i, pp := range patternParts {
+		if matched, _ := path.Match(pp, keyParts[i]); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// QueryValues returns the values whose keys are selected by any of the given
+// query patterns. Internal keys, which start with "__", are never selected. It
+// returns an error naming every pattern that selects nothing.
+func QueryValues(values storage.KV, patterns []string) (storage.KV, error) {
+	out := storage.New()
+	missing := []string{}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		found := false
+		for _, key := range values.AllKeys() {
+			if storage.IsInternalKey(key) || !matchQuery(pattern, key) {
+				continue
+			}
+
+			out.Set(key, values.Get(key))
+			found = true
+		}
+
+		if !found {
+			missing = append(missing, pattern)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no value found for %s", strings.Join(missing, ", "))
+	}
+
+	return out, nil
+}
+
+// SingleQueryKey returns the key named by the query if the query selects
+// exactly one value by name, without any wildcards. Otherwise, it returns an
+// empty string.
+func SingleQueryKey(values storage.KV, patterns []string) string {
+	if len(patterns) != 1 || isWildcard(patterns[0]) {
+		return ""
+	}
+
+	key := strings.ToLower(patterns[0])
+	keys := values.AllKeys()
+	if len(keys) != 1 || keys[0] != key {
+		return ""
+	}
+
+	return key
+}
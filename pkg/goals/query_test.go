@@ -0,0 +1,35 @@
+package goals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+func TestQueryValues(t *testing.T) {
+	values := storage.New()
+	values.Set("release.version", "1.2.0")
+	values.Set("release.description", "Stuff.")
+	values.Set("git.head", "abc123")
+	values.Set("git.tag.name", "v1.1.0")
+	values.Set("__export__.git.head", true)
+
+	q, err := QueryValues(values, []string{"git.*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git.head", "git.tag.name"}, sortedKeys(q))
+	assert.Equal(t, "", SingleQueryKey(q, []string{"git.*"}))
+
+	q, err = QueryValues(values, []string{"Release.Version"})
+	require.NoError(t, err)
+	assert.Equal(t, "release.version", SingleQueryKey(q, []string{"Release.Version"}))
+
+	q, err = QueryValues(values, []string{"release", "git.head"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git.head", "release.description", "release.version"}, sortedKeys(q))
+
+	_, err = QueryValues(values, []string{"release.version", "release.tag", "info.*"})
+	assert.EqualError(t, err, "no value found for release.tag, info.*")
+}
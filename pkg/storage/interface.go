@@ -110,10 +110,8 @@ type KV interface {
 	RegisterAlias(string, string)
 }
 
-// TODO I created Requirements a bit too early. Does this make sense or shall it be deleted as deadcode?
-
 // Requirements is an additional layer that can be added to a KV to apply
-// requirements to KVs.
+// requirements to KVs. See KVReq for the implementation.
 type Requirements interface {
 	// MarkRequired marks the name key as a required value that must be set.
 	MarkRequired(string)
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"strings"
+	"time"
+)
+
+// InternalPrefix is the prefix shared by the keys zedpm uses internally to
+// record facts about other keys, such as ExportPrefix and RequiredPrefix.
+const InternalPrefix = "__"
+
+// IsInternalKey returns true if the key is one used internally to record facts
+// about other keys, rather than a property in its own right.
+func IsInternalKey(key string) bool {
+	return strings.HasPrefix(key, InternalPrefix)
+}
+
+// Verify that KVPub is a KV.
+var _ KV = &KVPub{}
+
+// KVPub is a KV that hides the internal keys of the wrapped KV, such as
+// "__export__.<key>" and "__required__.<key>". Every other value is visible as
+// usual.
+type KVPub struct {
+	KV
+}
+
+// PublicOnly wraps the given KV in a KVPub.
+func PublicOnly(values KV) *KVPub {
+	return &KVPub{values}
+}
+
+// AllKeys returns the keys that are not internal.
+func (p *KVPub) AllKeys() []string {
+	keys := p.KV.AllKeys()
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if IsInternalKey(key) {
+			continue
+		}
+		out = append(out, key)
+	}
+	return out
+}
+
+// AllSettings returns the values that are not internal.
+func (p *KVPub) AllSettings() map[string]any {
+	out := New()
+	for _, key := range p.AllKeys() {
+		out.Set(key, p.KV.Get(key))
+	}
+	return out.AllSettings()
+}
+
+// AllSettingsStrings returns the values that are not internal.
+func (p *KVPub) AllSettingsStrings() map[string]string {
+	keys := p.AllKeys()
+	out := make(map[string]string, len(keys))
+	for _, key := range keys {
+		out[key] = p.KV.GetString(key)
+	}
+	return out
+}
+
+// getp calls getter for the key, unless the key is internal, in which case it
+// returns the zero value.
+func getp[T any](p *KVPub, key string, getter func(KV, string) T) T {
+	if IsInternalKey(key) {
+		return zero[T]()
+	}
+	return getter(p.KV, key)
+}
+
+// Get returns the value of the given key, or nil if the key is internal.
+func (p *KVPub) Get(key string) any {
+	return getp[any](p, key, KV.Get)
+}
+
+// GetBool returns the value of the given key as a boolean, or the zero value if
+// the key is internal.
+func (p *KVPub) GetBool(key string) bool {
+	return getp[bool](p, key, KV.GetBool)
+}
+
+// GetDuration returns the value of the given key as a duration, or the zero
+// value if the key is internal.
+func (p *KVPub) GetDuration(key string) time.Duration {
+	return getp[time.Duration](p, key, KV.GetDuration)
+}
+
+// GetFloat64 returns the value of the given key as a 64-bit float, or the zero
+// value if the key is internal.
+func (p *KVPub) GetFloat64(key string) float64 {
+	return getp[float64](p, key, KV.GetFloat64)
+}
+
+// GetInt returns the value of the given key as an integer, or the zero value if
+// the key is internal.
+func (p *KVPub) GetInt(key string) int {
+	return getp[int](p, key, KV.GetInt)
+}
+
+// GetInt32 returns the value of the given key as a 32-bit integer, or the zero
+// value if the key is internal.
+func (p *KVPub) GetInt32(key string) int32 {
+	return getp[int32](p, key, KV.GetInt32)
+}
+
+// GetInt64 returns the value of the given key as a 64-bit integer, or the zero
+// value if the key is internal.
+func (p *KVPub) GetInt64(key string) int64 {
+	return getp[int64](p, key, KV.GetInt64)
+}
+
+// GetIntSlice returns the value of the given key as a slice of integers, or the
+// zero value if the key is internal.
+func (p *KVPub) GetIntSlice(key string) []int {
+	return getp[[]int](p, key, KV.GetIntSlice)
+}
+
+// GetString returns the value of the given key as a string, or the zero value
+// if the key is internal.
+func (p *KVPub) GetString(key string) string {
+	return getp[string](p, key, KV.GetString)
+}
+
+// GetStringMap returns the value of the given key as a map, or the zero value
+// if the key is internal.
+func (p *KVPub) GetStringMap(key string) map[string]any {
+	return getp[map[string]any](p, key, KV.GetStringMap)
+}
+
+// GetStringMapString returns the value of the given key as a map of strings, or
+// the zero value if the key is internal.
+func (p *KVPub) GetStringMapString(key string) map[string]string {
+	return getp[map[string]string](p, key, KV.GetStringMapString)
+}
+
+// GetStringMapStringSlice returns the value of the given key as a map of string
+// slices, or the zero value if the key is internal.
+func (p *KVPub) GetStringMapStringSlice(key string) map[string][]string {
+	return getp[map[string][]string](p, key, KV.GetStringMapStringSlice)
+}
+
+// GetStringSlice returns the value of the given key as a slice of strings, or
+// the zero value if the key is internal.
+func (p *KVPub) GetStringSlice(key string) []string {
+	return getp[[]string](p, key, KV.GetStringSlice)
+}
+
+// GetTime returns the value of the given key as a time, or the zero value if
+// the key is internal.
+func (p *KVPub) GetTime(key string) time.Time {
+	return getp[time.Time](p, key, KV.GetTime)
+}
+
+// GetUint returns the value of the given key as an unsigned integer, or the
+// zero value if the key is internal.
+func (p *KVPub) GetUint(key string) uint {
+	return getp[uint](p, key, KV.GetUint)
+}
+
+// GetUint16 returns the value of the given key as a 16-bit unsigned integer, or
+// the zero value if the key is internal.
+func (p *KVPub) GetUint16(key string) uint16 {
+	return getp[uint16](p, key, KV.GetUint16)
+}
+
+// GetUint32 returns the value of the given key as a 32-bit unsigned integer, or
+// the zero value if the key is internal.
+func (p *KVPub) GetUint32(key string) uint32 {
+	return getp[uint32](p, key, KV.GetUint32)
+}
+
+// GetUint64 returns the value of the given key as a 64-bit unsigned integer, or
+// the zero value if the key is internal.
+func (p *KVPub) GetUint64(key string) uint64 {
+	return getp[uint64](p, key, KV.GetUint64)
+}
+
+// Sub returns the values under the given key, or nil if the key is internal.
+func (p *KVPub) Sub(key string) KV {
+	return getp[KV](p, key, KV.Sub)
+}
+
+// IsSet returns true if the given key is set and is not internal.
+func (p *KVPub) IsSet(key string) bool {
+	return getp[bool](p, key, KV.IsSet)
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicOnly(t *testing.T) {
+	t.Parallel()
+
+	kv := New()
+	kv.UpdateStrings(map[string]string{
+		"release.version":                "1.2.3",
+		ExportPrefix + "release.version": "true",
+		RequiredPrefix + "git.tag":       `{"description":"tag"}`,
+		"__default__.git.tag":            "v1.2.3",
+	})
+
+	pub := PublicOnly(kv)
+	assert.Equal(t, []string{"release.version"}, pub.AllKeys())
+	assert.Equal(t, map[string]string{"release.version": "1.2.3"}, pub.AllSettingsStrings())
+	assert.Equal(t, map[string]any{
+		"release": map[string]any{"version": "1.2.3"},
+	}, pub.AllSettings())
+	assert.Equal(t, "1.2.3", pub.GetString("release.version"))
+	assert.False(t, pub.IsSet(RequiredPrefix+"git.tag"))
+	assert.Empty(t, pub.GetString("__default__.git.tag"))
+}
@@ -18,17 +18,15 @@ var (
 // KVMem is the base building block of the storage package. It provides a basic
 // in-memory store of a hierarchy of key/value pairs.
 type KVMem struct {
-	prefix       string
-	values       map[string]any
-	requirements map[string]struct{}
-	aliases      map[string]string
+	prefix  string
+	values  map[string]any
+	aliases map[string]string
 }
 
 // New returns a new, empty KVMem.
 func New() *KVMem {
 	return &KVMem{
-		values:       make(map[string]any, 10),
-		requirements: make(map[string]struct{}, 10),
+		values: make(map[string]any, 10),
 	}
 }
 
@@ -315,10 +313,9 @@ func (m *KVMem) Sub(key string) KV {
 	v := m.get(key)
 	if _, isStringMap := v.(map[string]any); isStringMap {
 		return &KVMem{
-			prefix:       key,
-			values:       m.values,
-			requirements: m.requirements,
-			aliases:      m.aliases,
+			prefix:  key,
+			values:  m.values,
+			aliases: m.aliases,
 		}
 	}
 	return nil
@@ -361,35 +358,31 @@ func (m *KVMem) RegisterAlias(alias, key string) {
 }
 
 // MarkRequired adds the given key to the list of required keys.
+//
+// Deprecated: Use WithRequirements(m).MarkRequired instead, which records the
+// requirement as a RequiredPrefix key that survives being passed to plugins.
 func (m *KVMem) MarkRequired(key string) {
-	m.requirements[m.key(key)] = struct{}{}
+	WithRequirements(m).MarkRequired(key)
 }
 
 // IsRequired returns true if the given key has been marked as required.
+//
+// Deprecated: Use WithRequirements(m).IsRequired instead.
 func (m *KVMem) IsRequired(key string) bool {
-	_, exists := m.requirements[m.key(key)]
-	return exists
+	return WithRequirements(m).IsRequired(key)
 }
 
 // IsPassingRequirements returns true if all the required keys have been set.
+//
+// Deprecated: Use WithRequirements(m).IsPassingRequirements instead.
 func (m *KVMem) IsPassingRequirements() bool {
-	for k := range m.requirements {
-		if !m.IsSet(k) {
-			return false
-		}
-	}
-	return true
+	return WithRequirements(m).IsPassingRequirements()
 }
 
 // MissingRequirements returns all the keys that are missing a value from the
 // list of required keys.
+//
+// Deprecated: Use WithRequirements(m).MissingRequirements instead.
 func (m *KVMem) MissingRequirements() []string {
-	missing := make([]string, 0, len(m.requirements))
-	for k := range m.requirements {
-		if !m.IsSet(k) {
-			missing = append(missing, k)
-		}
-	}
-	sort.Strings(missing)
-	return missing
+	return WithRequirements(m).MissingRequirements()
 }
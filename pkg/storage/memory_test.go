@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKVMemRequirements(t *testing.T) {
+	t.Parallel()
+
+	kv := New()
+	kv.MarkRequired("release.version")
+	kv.MarkRequired("release.description")
+	kv.Set("release.description", "Stuff.")
+
+	assert.True(t, kv.IsRequired("release.version"))
+	assert.False(t, kv.IsRequired("release.date"))
+	assert.False(t, kv.IsPassingRequirements())
+	assert.Equal(t, []string{"release.version"}, kv.MissingRequirements())
+	assert.True(t, WithRequirements(kv).IsRequired("release.version"))
+
+	kv.Set("release.version", "1.2.3")
+	assert.True(t, kv.IsPassingRequirements())
+	assert.Empty(t, kv.MissingRequirements())
+}
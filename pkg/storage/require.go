@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+)
+
+// RequiredPrefix is the prefix used to mark a key as required. The key
+// "__required__.<key>" is set to mark "<key>" as required. Because the mark is
+// just another property, it survives being passed between the master process
+// and plugins like any other property. The value of the mark may be used to
+// carry a description of the requirement.
+const RequiredPrefix = "__required__."
+
+// Verify that KVReq is a KV and implements Requirements.
+var (
+	_ KV           = &KVReq{}
+	_ Requirements = &KVReq{}
+)
+
+// KVReq is a KV that tracks required keys within the wrapped KV itself.
+type KVReq struct {
+	KV
+}
+
+// WithRequirements wraps the given KV in a KVReq.
+func WithRequirements(values KV) *KVReq {
+	return &KVReq{values}
+}
+
+// MarkRequired marks the given key as required. If the key is already marked,
+// the existing mark is kept as-is.
+func (r *KVReq) MarkRequired(key string) {
+	if r.IsRequired(key) {
+		return
+	}
+	r.KV.Set(RequiredPrefix+key, "")
+}
+
+// IsRequired returns true if the given key has been marked as required.
+func (r *KVReq) IsRequired(key string) bool {
+	return r.KV.IsSet(RequiredPrefix + key)
+}
+
+// RequiredKeys returns all the keys that have been marked as required.
+func (r *KVReq) RequiredKeys() []string {
+	keys := r.KV.AllKeys()
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasPrefix(key, RequiredPrefix) {
+			continue
+		}
+		out = append(out, key[len(RequiredPrefix):])
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsPassingRequirements returns true if all the required keys have been set.
+func (r *KVReq) IsPassingRequirements() bool {
+	return len(r.MissingRequirements()) == 0
+}
+
+// MissingRequirements returns all the keys that are missing a value from the
+// list of required keys.
+func (r *KVReq) MissingRequirements() []string {
+	keys := r.RequiredKeys()
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !r.KV.IsSet(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,144 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+
+	"github.com/zostay/zedpm/pkg/conventional"
+)
+
+// Bump identifies the part of a version to increment.
+type Bump int
+
+const (
+	// BumpNone means no change to the version is needed.
+	BumpNone Bump = 0 + iota
+
+	// BumpPreRelease increments the pre-release number of the version.
+	BumpPreRelease
+
+	// BumpPatch increments the patch number of the version.
+	BumpPatch
+
+	// BumpMinor increments the minor number of the version.
+	BumpMinor
+
+	// BumpMajor increments the major number of the version.
+	BumpMajor
+)
+
+// DefaultPreReleaseID is the identifier used to start a new pre-release when
+// the previous version was not a pre-release.
+const DefaultPreReleaseID = "rc"
+
+// bumpNames maps the names of each Bump to the Bump.
+var bumpNames = map[string]Bump{
+	"none":       BumpNone,
+	"prerelease": BumpPreRelease,
+	"patch":      BumpPatch,
+	"minor":      BumpMinor,
+	"major":      BumpMajor,
+}
+
+// ParseBump returns the Bump with the given name, which must be one of "major",
+// "minor", "patch", "prerelease", or "none".
+func ParseBump(name string) (Bump, error) {
+	if b, ok := bumpNames[strings.ToLower(name)]; ok {
+		return b, nil
+	}
+	return BumpNone, fmt.Errorf("unknown version bump %q, expected major, minor, patch, or prerelease", name)
+}
+
+// String returns the name of the Bump.
+func (b Bump) String() string {
+	for name, nb := range bumpNames {
+		if nb == b {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// Next returns the version following the given version when bumped as given.
+// When the given version is a pre-release, bumping the major, minor, or patch
+// number releases the version the pre-release leads up to, if that is large
+// enough. For example, a patch bump of 1.2.0-rc.1 is 1.2.0, but a major bump of
+// 1.2.0-rc.1 is 2.0.0. A pre-release bump of 1.2.0-rc.1 is 1.2.0-rc.2 and a
+// pre-release bump of 1.2.0 is 1.2.1-rc.1.
+func Next(v semver.Version, b Bump) semver.Version {
+	next := v
+	next.Metadata = ""
+
+	isPreRelease := v.PreRelease != ""
+	switch b {
+	case BumpNone:
+		return v
+	case BumpPreRelease:
+		if isPreRelease {
+			next.PreRelease = nextPreRelease(v.PreRelease)
+		} else {
+			next.BumpPatch()
+			next.PreRelease = semver.PreRelease(DefaultPreReleaseID + ".1")
+		}
+	case BumpPatch:
+		if !isPreRelease {
+			next.BumpPatch()
+		}
+		next.PreRelease = ""
+	case BumpMinor:
+		if !isPreRelease || v.Patch != 0 {
+			next.BumpMinor()
+		}
+		next.PreRelease = ""
+	case BumpMajor:
+		if !isPreRelease || v.Minor != 0 || v.Patch != 0 {
+			next.BumpMajor()
+		}
+		next.PreRelease = ""
+	}
+
+	return next
+}
+
+// nextPreRelease increments the last numeric identifier of the pre-release or
+// adds a new ".1" identifier if the last identifier is not numeric.
+func nextPreRelease(pre semver.PreRelease) semver.PreRelease {
+	ids := pre.Slice()
+	last := ids[len(ids)-1]
+	if n, err := strconv.ParseUint(last, 10, 64); err == nil {
+		ids[len(ids)-1] = strconv.FormatUint(n+1, 10)
+	} else {
+		ids = append(ids, "1")
+	}
+	return semver.PreRelease(strings.Join(ids, "."))
+}
+
+// Infer returns the Bump implied by the given conventional commits. A breaking
+// change implies a major bump (or a minor bump before version 1.0.0), a feature
+// implies a minor bump, and a fix or performance improvement implies a patch
+// bump. Other commits, such as "docs" or "chore", do not call for a release, so
+// BumpNone is returned if there are only commits like those or no commits.
+func Infer(v semver.Version, commits []*conventional.Commit) Bump {
+	b := BumpNone
+	for _, c := range commits {
+		var cb Bump
+		switch {
+		case c.Breaking && v.Major == 0:
+			cb = BumpMinor
+		case c.Breaking:
+			cb = BumpMajor
+		case c.IsFeature():
+			cb = BumpMinor
+		case c.IsFix(), c.IsPerformance():
+			cb = BumpPatch
+		}
+
+		if cb > b {
+			b = cb
+		}
+	}
+	return b
+}
@@ -0,0 +1,68 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zostay/zedpm/pkg/conventional"
+)
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		from string
+		bump Bump
+		to   string
+	}{
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpPreRelease, "1.2.4-rc.1"},
+		{"1.2.4-rc.1", BumpPreRelease, "1.2.4-rc.2"},
+		{"1.2.4-beta", BumpPreRelease, "1.2.4-beta.1"},
+		{"1.2.4-rc.2", BumpPatch, "1.2.4"},
+		{"1.3.0-rc.2", BumpMinor, "1.3.0"},
+		{"1.2.4-rc.2", BumpMinor, "1.3.0"},
+		{"2.0.0-rc.1", BumpMajor, "2.0.0"},
+		{"1.2.3+build", BumpNone, "1.2.3+build"},
+	}
+
+	for _, test := range tests {
+		next := Next(*semver.New(test.from), test.bump)
+		assert.Equal(t, test.to, next.String(), "%s bumped by %s", test.from, test.bump)
+	}
+}
+
+func TestInfer(t *testing.T) {
+	parse := func(msgs ...string) []*conventional.Commit {
+		out := make([]*conventional.Commit, 0, len(msgs))
+		for _, msg := range msgs {
+			if c := conventional.Parse(msg); c != nil {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	v1 := *semver.New("1.0.0")
+	v0 := *semver.New("0.4.0")
+
+	assert.Equal(t, BumpNone, Infer(v1, parse("Merge branch 'foo'")))
+	assert.Equal(t, BumpPatch, Infer(v1, parse("fix: oops", "docs(readme): typo")))
+	assert.Equal(t, BumpPatch, Infer(v1, parse("perf: faster", "chore: tidy")))
+	assert.Equal(t, BumpNone, Infer(v1, parse("docs: typo", "chore: tidy", "ci: cache", "test: more", "style: gofmt")))
+	assert.Equal(t, BumpMinor, Infer(v1, parse("fix: oops", "feat(cmd): new flag")))
+	assert.Equal(t, BumpMajor, Infer(v1, parse("feat!: rewrite")))
+	assert.Equal(t, BumpMajor, Infer(v1, parse("fix: thing\n\nBREAKING CHANGE: gone")))
+	assert.Equal(t, BumpMinor, Infer(v0, parse("feat!: rewrite")))
+}
+
+func TestParseBump(t *testing.T) {
+	b, err := ParseBump("Minor")
+	assert.NoError(t, err)
+	assert.Equal(t, BumpMinor, b)
+
+	_, err = ParseBump("huge")
+	assert.Error(t, err)
+}
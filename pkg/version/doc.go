@@ -0,0 +1,3 @@
+// Package version provides tools for computing the next version of a release
+// from a previous version.
+package version
@@ -52,6 +52,13 @@ func (e *InterfaceExecutor) Define(values map[string]string) {
 	e.m.Define(values)
 }
 
+// Reset discards all the properties that were defined or changed during
+// previous executions. This must be called before executing the same goal
+// again with different properties.
+func (e *InterfaceExecutor) Reset() {
+	e.m.Reset()
+}
+
 // tryCancel executes plugin.Interface.Cancel on the object and internally
 // handles the situation where the cancel itself also has an error.
 func (e *InterfaceExecutor) tryCancel(
@@ -55,6 +55,13 @@ func (ti *Interface) Define(values map[string]string) {
 	ti.pctx.ApplyChanges(values)
 }
 
+// Reset discards all the properties that were defined or changed during
+// previous executions, so that the interface may be used to execute again from
+// a clean slate.
+func (ti *Interface) Reset() {
+	ti.pctx = NewContext(storage.New())
+}
+
 // Implements calls Implements on all the associated plugins and returns a
 // combined list of all the tasks defined by all the plugins. It fails with an
 // error if any plugin fails with an error.
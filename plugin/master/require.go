@@ -0,0 +1,75 @@
+package master
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/group"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// MissingRequirements prepares every task in the given phases so that each may
+// declare the properties it requires via plugin.Require. Each task is canceled
+// immediately after it has been prepared. It returns the requirements that are
+// not satisfied by the configuration or the properties defined so far, sorted
+// by key.
+//
+// This should be called before the first phase is executed so that missing
+// properties are discovered before any work is performed.
+func (e *InterfaceExecutor) MissingRequirements(
+	ctx context.Context,
+	phases []*group.Phase,
+) ([]plugin.Requirement, error) {
+	// make the properties defined so far visible to the plugins, which only
+	// see the changes of previous phases, so that tasks may use them to
+	// suggest defaults while being prepared
+	e.m.pctx.nextPhase()
+
+	missing := map[string]plugin.Requirement{}
+	seen := map[string]struct{}{}
+	for _, phase := range phases {
+		for _, taskDesc := range phase.Tasks() {
+			taskName := taskDesc.Name()
+			task, err := e.prepare(ctx, taskName)
+			if err != nil {
+				return nil, format.WrapErr(err, "failed to prepare task %q", taskName)
+			}
+			e.tryCancel(ctx, taskName, task, "Requirements")
+
+			props, err := e.m.properties(taskName)
+			if err != nil {
+				return nil, format.WrapErr(err, "failed to configure task %q", taskName)
+			}
+
+			// only check the requirements this task added, since the
+			// requirements of earlier tasks were checked against their own
+			// configuration
+			for _, req := range plugin.Requirements(props) {
+				if _, alreadySeen := seen[req.Key]; alreadySeen {
+					continue
+				}
+				seen[req.Key] = struct{}{}
+
+				if !props.IsSet(req.Key) {
+					missing[req.Key] = req
+				}
+			}
+		}
+	}
+
+	out := make([]plugin.Requirement, 0, len(missing))
+	for _, req := range missing {
+		out = append(out, req)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+
+	return out, nil
+}
+
+// properties returns the properties as visible to the named task, without
+// regard to any plugin configuration.
+func (ti *Interface) properties(taskName string) (storage.KV, error) {
+	return ti.cfg.ToKV(ti.pctx.properties, taskName, ti.targetName, "")
+}
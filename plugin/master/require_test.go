@@ -0,0 +1,110 @@
+package master_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/config"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/group"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+	"github.com/zostay/zedpm/plugin/master"
+	"github.com/zostay/zedpm/plugin/metal"
+)
+
+// requirePlugin is a plugin with a single /test/check/require task that
+// requires the properties named by its keys.
+type requirePlugin struct {
+	reqs []plugin.Requirement
+}
+
+func (p *requirePlugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
+	return []plugin.TaskDescription{
+		goals.DescribeTest().Task("check", "require", "Require properties."),
+	}, nil
+}
+
+func (p *requirePlugin) Goal(_ context.Context, name string) (plugin.GoalDescription, error) {
+	if name == goals.NameTest {
+		return goals.DescribeTest(), nil
+	}
+	return nil, plugin.ErrUnsupportedGoal
+}
+
+func (p *requirePlugin) Prepare(ctx context.Context, taskName string) (plugin.Task, error) {
+	for _, req := range p.reqs {
+		plugin.Require(ctx, req)
+	}
+	return &plugin.TaskBoilerplate{}, nil
+}
+
+func (p *requirePlugin) Cancel(context.Context, plugin.Task) error   { return nil }
+func (p *requirePlugin) Complete(context.Context, plugin.Task) error { return nil }
+
+// newExecutor serves each of the given plugins from the current process and
+// returns an executor for them along with the phases of the named goal.
+func newExecutor(
+	t *testing.T,
+	ifaces map[string]plugin.Interface,
+	goalName string,
+) (*master.InterfaceExecutor, []*group.Phase) {
+	t.Helper()
+
+	logger := hclog.NewNullLogger()
+	cfg := &config.Config{Properties: storage.New().RO()}
+
+	clients := metal.Clients{}
+	for name, iface := range ifaces {
+		client, err := metal.LoadLocalPlugin(iface, logger, io.Discard, io.Discard)
+		require.NoError(t, err)
+		clients[name] = client
+	}
+	t.Cleanup(func() { metal.KillPlugins(clients) })
+
+	dispensed, err := metal.DispenseAll(clients)
+	require.NoError(t, err)
+
+	e := master.NewExecutor(logger, master.NewInterface(logger, cfg, dispensed))
+	goalGroups, err := e.PotentialGoalsPhasesAndTasks(context.Background())
+	require.NoError(t, err)
+
+	for _, goal := range goalGroups {
+		if goal.Name == goalName {
+			return e, goal.ExecutionPhases()
+		}
+	}
+
+	require.Failf(t, "goal not found", "goal %q is not implemented", goalName)
+	return nil, nil
+}
+
+func TestMissingRequirements(t *testing.T) {
+	e, phases := newExecutor(t, map[string]plugin.Interface{
+		"require": &requirePlugin{reqs: []plugin.Requirement{
+			{Key: "release.version", Description: "Release version"},
+			{Key: "git.tag", Default: "v1.2.3"},
+			{Key: "release.description"},
+		}},
+	}, goals.NameTest)
+
+	ctx := context.Background()
+	e.Define(map[string]string{"release.description": "Stuff."})
+
+	missing, err := e.MissingRequirements(ctx, phases)
+	require.NoError(t, err)
+	assert.Equal(t, []plugin.Requirement{
+		{Key: "git.tag", Default: "v1.2.3"},
+		{Key: "release.version", Description: "Release version"},
+	}, missing)
+
+	e.Define(map[string]string{"release.version": "1.2.3", "git.tag": "v1.2.3"})
+	missing, err = e.MissingRequirements(ctx, phases)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
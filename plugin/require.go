@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+// DefaultPrefix is the prefix used to record the suggested default value for a
+// required property. It is kept separate from the requirement mark so that one
+// task may suggest a default for a property required by another.
+const DefaultPrefix = "__default__."
+
+// Requirement describes a property that must be set before a task may run.
+type Requirement struct {
+	// Key is the name of the required property.
+	Key string `json:"-"`
+
+	// Description is a short, human-readable description of the property,
+	// which is used when prompting for a value.
+	Description string `json:"description,omitempty"`
+
+	// Pattern is an optional regular expression the value must match.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Default is an optional value to suggest or use when the value is not
+	// set.
+	Default string `json:"-"`
+}
+
+// Check returns an error if the given value is not acceptable for this
+// requirement.
+func (r *Requirement) Check(value string) error {
+	if value == "" {
+		return fmt.Errorf("a value for %q is required", r.Key)
+	}
+
+	if r.Pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("requirement for %q has a bad pattern: %w", r.Key, err)
+	}
+
+	if !re.MatchString(value) {
+		return fmt.Errorf("value %q for %q does not match %s", value, r.Key, r.Pattern)
+	}
+
+	return nil
+}
+
+// Require declares that the current task requires the described property. This
+// should be called while the task is being prepared, which gives the master
+// process the chance to verify that every required property is set before the
+// first phase of a goal runs.
+func Require(ctx context.Context, req Requirement) {
+	desc, _ := json.Marshal(&req)
+
+	AtomicProperties(ctx, func(kv storage.KV) {
+		kv.Set(storage.RequiredPrefix+req.Key, string(desc))
+		if req.Default != "" {
+			kv.Set(DefaultPrefix+req.Key, req.Default)
+		}
+	})
+}
+
+// SuggestDefault records a suggested default value for a required property
+// without marking it as required.
+func SuggestDefault(ctx context.Context, key, value string) {
+	Set(ctx, DefaultPrefix+key, value)
+}
+
+// Requirements returns all the requirements that have been declared in the
+// given properties.
+func Requirements(kv storage.KV) []Requirement {
+	keys := storage.WithRequirements(kv).RequiredKeys()
+	reqs := make([]Requirement, len(keys))
+	for i, key := range keys {
+		req := &reqs[i]
+		if desc := kv.GetString(storage.RequiredPrefix + key); desc != "" {
+			_ = json.Unmarshal([]byte(desc), req)
+		}
+		req.Key = key
+		req.Default = kv.GetString(DefaultPrefix + key)
+	}
+	return reqs
+}
+
+// MissingRequirements returns all the requirements that have been declared in
+// the given properties, but which are not yet set.
+func MissingRequirements(kv storage.KV) []Requirement {
+	reqs := Requirements(kv)
+	missing := make([]Requirement, 0, len(reqs))
+	for _, req := range reqs {
+		if kv.IsSet(req.Key) {
+			continue
+		}
+		missing = append(missing, req)
+	}
+	return missing
+}
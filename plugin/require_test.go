@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/pkg/storage"
+)
+
+func TestRequirementCheck(t *testing.T) {
+	t.Parallel()
+
+	req := &Requirement{Key: "release.version", Pattern: `^\d+\.\d+\.\d+$`}
+	assert.NoError(t, req.Check("1.2.3"))
+	assert.EqualError(t, req.Check(""), `a value for "release.version" is required`)
+	assert.EqualError(t, req.Check("v1.2.3"),
+		`value "v1.2.3" for "release.version" does not match ^\d+\.\d+\.\d+$`)
+
+	req = &Requirement{Key: "x", Pattern: "("}
+	assert.ErrorContains(t, req.Check("y"), `requirement for "x" has a bad pattern`)
+
+	req = &Requirement{Key: "x"}
+	assert.NoError(t, req.Check("anything"))
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	kv := storage.New()
+	kv.Set("git.tag", "v1.2.3")
+	ctx := InitializeContext(context.Background(), NewContext(log.New(hclog.NewNullLogger()), kv))
+
+	Require(ctx, Requirement{
+		Key:         "release.version",
+		Description: "Release version",
+		Pattern:     `^\d`,
+	})
+	Require(ctx, Requirement{Key: "git.tag", Default: "v0.0.0"})
+	SuggestDefault(ctx, "release.version", "1.2.3")
+
+	// a suggestion alone does not make a property required
+	SuggestDefault(ctx, "release.description", "Stuff.")
+
+	props := KV(ctx)
+	assert.Equal(t, []Requirement{
+		{Key: "git.tag", Default: "v0.0.0"},
+		{Key: "release.version", Description: "Release version", Pattern: `^\d`, Default: "1.2.3"},
+	}, Requirements(props))
+
+	missing := MissingRequirements(props)
+	require.Len(t, missing, 1)
+	assert.Equal(t, "release.version", missing[0].Key)
+}
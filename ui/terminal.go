@@ -78,7 +78,7 @@ func (t *Terminal) detectTTY() {
 }
 
 func (t *Terminal) sigwinch() {
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGWINCH)
 	for range signals {
 		t.detectTTY()
@@ -0,0 +1,54 @@
+package changelogImpl
+
+import (
+	"context"
+	"io"
+
+	"github.com/zostay/zedpm/pkg/changes"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// AddChangelogTask implements the /generate/entry/changelog task, which adds the
+// entry described by the changelog.entry.* properties to the unreleased
+// section of the changelog.
+type AddChangelogTask struct {
+	plugin.TaskBoilerplate
+}
+
+// AddEntry adds the configured entry to the changelog. Nothing is done unless
+// changelog.entry.text is set.
+func (t *AddChangelogTask) AddEntry(ctx context.Context) error {
+	entry, err := changes.GetPropertyChangelogEntry(ctx)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = RewriteChangelog(ctx, func(r io.Reader, w io.Writer) error {
+		return chgFormat.AddUnreleased(r, w, []changes.Entry{*entry})
+	})
+	if err != nil {
+		return err
+	}
+
+	plugin.Logger(ctx,
+		"changelog", changes.GetPropertyChangelogFile(ctx),
+		"entry", entry.Text,
+	).Info("Added entry to the changelog.")
+
+	return nil
+}
+
+// Run prepares the AddEntry operation.
+func (t *AddChangelogTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(t.AddEntry),
+		},
+	}, nil
+}
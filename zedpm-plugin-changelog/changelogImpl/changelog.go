@@ -3,6 +3,7 @@ package changelogImpl
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/zostay/zedpm/format"
@@ -11,22 +12,6 @@ import (
 	"github.com/zostay/zedpm/plugin"
 )
 
-const (
-	PropertyChangelogFile = "changelog.file"
-)
-
-// DefaultChangelog is the changelog file path to use when none is configured.
-const DefaultChangelog = "Changes.md"
-
-// GetPropertyChangelogFile gets the name of the changelog file from the configuration or
-// returns the default value.
-func GetPropertyChangelogFile(ctx context.Context) string {
-	if plugin.IsSet(ctx, PropertyChangelogFile) {
-		return plugin.GetString(ctx, PropertyChangelogFile)
-	}
-	return DefaultChangelog
-}
-
 // CheckMode examines the context to determine what mode to use when performing
 // linter checks.
 //
@@ -46,15 +31,81 @@ func CheckMode(ctx context.Context) changes.CheckMode {
 	return mode
 }
 
+// RewriteChangelog rewrites the changelog file by passing its contents through
+// the given edit function. The edited changelog is written to a new file, which
+// then replaces the original. The changelog is added to the list of files to
+// be added to version control.
+func RewriteChangelog(
+	ctx context.Context,
+	edit func(r io.Reader, w io.Writer) error,
+) error {
+	changelog := changes.GetPropertyChangelogFile(ctx)
+	r, err := os.Open(changelog)
+	if err != nil {
+		return format.WrapErr(err, "unable to open %s", changelog)
+	}
+	defer r.Close()
+
+	newChangelog := changelog + ".new"
+	w, err := os.Create(newChangelog)
+	if err != nil {
+		return format.WrapErr(err, "unable to create %s", newChangelog)
+	}
+
+	plugin.ForCleanup(ctx, func() { _ = os.Remove(newChangelog) })
+
+	err = edit(r, w)
+	if err != nil {
+		_ = w.Close()
+		return format.WrapErr(err, "unable to edit %s", changelog)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return format.WrapErr(err, "unable to close %s", newChangelog)
+	}
+
+	err = os.Rename(newChangelog, changelog)
+	if err != nil {
+		return format.WrapErr(err, "unable to overwrite %s with %s", changelog, newChangelog)
+	}
+
+	plugin.ToAdd(ctx, changelog)
+
+	return nil
+}
+
+// ExtractSection reads the section for the given version from the changelog
+// using the configured changelog format.
+func ExtractSection(ctx context.Context, version string) (io.Reader, error) {
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog, err := os.Open(changes.GetPropertyChangelogFile(ctx))
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to open Changes file")
+	}
+	defer changelog.Close()
+
+	return chgFormat.ExtractSection(changelog, version)
+}
+
 // LintChangelog performs a check to ensure the changelog is ready for release.
 func LintChangelog(ctx context.Context) error {
-	changelog, err := os.Open(GetPropertyChangelogFile(ctx))
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	changelog, err := os.Open(changes.GetPropertyChangelogFile(ctx))
 	if err != nil {
 		return format.WrapErr(err, "unable to open Changes file")
 	}
+	defer changelog.Close()
 
-	linter := changes.NewLinter(changelog, CheckMode(ctx))
-	err = linter.Check()
+	err = chgFormat.Lint(changelog, CheckMode(ctx))
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -0,0 +1,84 @@
+package changelogImpl
+
+import (
+	"context"
+	"io"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/changes"
+	"github.com/zostay/zedpm/pkg/conventional"
+	zGit "github.com/zostay/zedpm/pkg/git"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// GenerateChangelogTask implements the /generate/changes/changelog task, which
+// adds entries to the unreleased section of the changelog from conventional
+// commits.
+type GenerateChangelogTask struct {
+	plugin.TaskBoilerplate
+	zGit.Git
+}
+
+// Setup opens the git repository to read its history. No remote is needed.
+func (t *GenerateChangelogTask) Setup(ctx context.Context) error {
+	return t.SetupGitHistory(ctx)
+}
+
+// ConventionalCommits returns the conventional commits made since the last
+// release tag, oldest first.
+func (t *GenerateChangelogTask) ConventionalCommits(ctx context.Context) ([]*conventional.Commit, error) {
+	commits, _, err := t.CommitsSinceRelease(goals.ReleaseTagPrefix(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ccs := make([]*conventional.Commit, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		if cc := conventional.Parse(commits[i].Message); cc != nil {
+			ccs = append(ccs, cc)
+		}
+	}
+
+	return ccs, nil
+}
+
+// GenerateChangelog adds entries generated from conventional commits to the
+// section of the changelog.
+func (t *GenerateChangelogTask) GenerateChangelog(ctx context.Context) error {
+	ccs, err := t.ConventionalCommits(ctx)
+	if err != nil {
+		return format.WrapErr(err, "unable to read commits since the last release")
+	}
+
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries := changes.EntriesFromCommits(ccs)
+	err = RewriteChangelog(ctx, func(r io.Reader, w io.Writer) error {
+		return chgFormat.AddUnreleased(r, w, entries)
+	})
+	if err != nil {
+		return err
+	}
+
+	plugin.Logger(ctx,
+		"changelog", changes.GetPropertyChangelogFile(ctx),
+		"commits", len(ccs),
+		"entries", len(entries),
+	).Info("Added changes from conventional commits to the changelog.")
+
+	return nil
+}
+
+// Run prepares the GenerateChangelog operation.
+func (t *GenerateChangelogTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(t.GenerateChangelog),
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+package changelogImpl
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestGenerateChangelogWithoutRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run("init", "-q", "-b", "master")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+	run("config", "commit.gpgsign", "false")
+	run("config", "tag.gpgsign", "false")
+	run("commit", "-q", "--allow-empty", "-m", "first")
+	run("tag", "v1.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "feat: add a thing")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Changes.md"), []byte("v1.0.0  2023-01-01\n\n * First.\n"), 0o644))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	logger := log.New(hclog.NewNullLogger())
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(logger, storage.New()))
+
+	task := &GenerateChangelogTask{}
+	require.NoError(t, task.Setup(ctx))
+	require.NoError(t, task.GenerateChangelog(ctx))
+
+	out, err := os.ReadFile(filepath.Join(dir, "Changes.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "Add a thing")
+}
@@ -5,7 +5,6 @@ import (
 	"io"
 
 	"github.com/zostay/zedpm/format"
-	"github.com/zostay/zedpm/pkg/changes"
 	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/plugin"
 )
@@ -21,7 +20,7 @@ type InfoChangelogTask struct {
 // version is used.
 func (t *InfoChangelogTask) ExtractChangelog(ctx context.Context) error {
 	version := goals.GetPropertyInfoVersion(ctx)
-	r, err := changes.ExtractSection(GetPropertyChangelogFile(ctx), version)
+	r, err := ExtractSection(ctx, version)
 	if err != nil {
 		return format.WrapErr(err, "failed to read changelog section")
 	}
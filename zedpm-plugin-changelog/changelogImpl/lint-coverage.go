@@ -0,0 +1,90 @@
+package changelogImpl
+
+import (
+	"context"
+	"os"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/changes"
+	zGit "github.com/zostay/zedpm/pkg/git"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// LintCoverageChangelogTask implements the /lint/coverage/changelog task, which
+// checks that the unreleased section of the changelog accounts for the commits
+// made since the last release.
+type LintCoverageChangelogTask struct {
+	plugin.TaskBoilerplate
+	zGit.Git
+}
+
+// Setup opens the git repository to read its history. No remote is needed.
+func (t *LintCoverageChangelogTask) Setup(ctx context.Context) error {
+	return t.SetupGitHistory(ctx)
+}
+
+// CheckCoverage compares the commits since the last release with the
+// unreleased entries of the changelog.
+func (t *LintCoverageChangelogTask) CheckCoverage(ctx context.Context) error {
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	changelog := changes.GetPropertyChangelogFile(ctx)
+	r, err := os.Open(changelog)
+	if err != nil {
+		return format.WrapErr(err, "unable to open %s", changelog)
+	}
+	defer r.Close()
+
+	entries, err := chgFormat.Unreleased(r)
+	if err != nil {
+		return format.WrapErr(err, "unable to read unreleased changes from %s", changelog)
+	}
+
+	commits, tag, err := t.CommitsSinceRelease(goals.ReleaseTagPrefix(ctx))
+	if err != nil {
+		return format.WrapErr(err, "unable to read commits since the last release")
+	}
+
+	ccs := make([]changes.CoverageCommit, len(commits))
+	for i, c := range commits {
+		ccs[i] = changes.CoverageCommit{
+			Message: c.Message,
+			IsMerge: c.NumParents() > 1,
+		}
+	}
+
+	logger := plugin.Logger(ctx,
+		"changelog", changelog,
+		"commits", len(commits),
+		"entries", len(entries),
+	)
+	if tag != nil {
+		logger = logger.With("tag", tag.Name)
+	}
+
+	err = changes.CheckCoverage(ccs, entries,
+		changes.GetPropertyChangelogCoverageRatio(ctx),
+		changes.GetPropertyChangelogCoveragePRs(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Changelog accounts for the commits since the last release.")
+
+	return nil
+}
+
+// Run prepares the CheckCoverage operation.
+func (t *LintCoverageChangelogTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(t.CheckCoverage),
+		},
+	}, nil
+}
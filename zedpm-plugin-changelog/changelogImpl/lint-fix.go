@@ -0,0 +1,85 @@
+package changelogImpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/changes"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// LintFixChangelogTask implements the /lint/fix/changelog task.
+type LintFixChangelogTask struct {
+	plugin.TaskBoilerplate
+}
+
+// FixChangelog repairs the problems in the changelog that can be repaired
+// automatically and reports the rest. The changelog is only rewritten if
+// something was repaired. Nothing is done unless changelog.fix is set.
+func FixChangelog(ctx context.Context) error {
+	if !changes.GetPropertyChangelogFix(ctx) {
+		plugin.Logger(ctx).Debug("Skipping changelog fixes because changelog.fix is not set")
+		return nil
+	}
+
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	fixer, canFix := chgFormat.(changes.Fixer)
+	if !canFix {
+		return fmt.Errorf("the %q changelog format does not support automatic fixes",
+			plugin.GetString(ctx, changes.PropertyChangelogFormat))
+	}
+
+	changelog := changes.GetPropertyChangelogFile(ctx)
+	orig, err := os.ReadFile(changelog)
+	if err != nil {
+		return format.WrapErr(err, "unable to read %s", changelog)
+	}
+
+	fixed := &bytes.Buffer{}
+	lintErr := fixer.Fix(bytes.NewReader(orig), fixed, CheckMode(ctx))
+	var failures *changes.Error
+	if lintErr != nil && !errors.As(lintErr, &failures) {
+		return format.WrapErr(lintErr, "unable to fix %s", changelog)
+	}
+
+	if !bytes.Equal(orig, fixed.Bytes()) {
+		err = RewriteChangelog(ctx, func(_ io.Reader, w io.Writer) error {
+			_, err := w.Write(fixed.Bytes())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		plugin.Logger(ctx,
+			"changelog", changelog,
+		).Info("Fixed problems found in the changelog.")
+	}
+
+	if lintErr != nil {
+		fmt.Println(lintErr)
+	}
+
+	return nil
+}
+
+// Run prepares the system to run the FixChangelog operation. It runs before the
+// other lint operations, so that they see the fixed changelog rather than one
+// that is being replaced.
+func (t *LintFixChangelogTask) Run(_ context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  40,
+			Action: plugin.OperationFunc(FixChangelog),
+		},
+	}, nil
+}
@@ -0,0 +1,28 @@
+package changelogImpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestLintFixRunsBeforeLint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixOps, err := (&LintFixChangelogTask{}).Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, fixOps, 1)
+
+	for _, task := range []plugin.Task{&LintChangelogTask{}, &LintCoverageChangelogTask{}} {
+		ops, err := task.Run(ctx)
+		require.NoError(t, err)
+		for _, op := range ops {
+			assert.Less(t, fixOps[0].Order, op.Order)
+		}
+	}
+}
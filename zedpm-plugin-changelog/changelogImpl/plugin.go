@@ -21,16 +21,25 @@ func (p *Plugin) Goal(context.Context, string) (plugin.GoalDescription, error) {
 
 // Implements returns the following tasks:
 //
+//	/generate/changes/changelog
+//	/generate/entry/changelog
 //	/info/release/description
-//	/lint/changelog
+//	/lint/coverage/changelog
+//	/lint/fix/changelog
+//	/lint/project-files/changelog
 //	/release/mint/changelog
 //	/release/publish/changelog
 func (p *Plugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
+	generate := goals.DescribeGenerate()
 	info := goals.DescribeInfo()
 	lint := goals.DescribeLint()
 	release := goals.DescribeRelease()
 	return []plugin.TaskDescription{
+		generate.Task("changes", "changelog", "Add changelog entries from conventional commits."),
+		generate.Task("entry", "changelog", "Add an entry to the changelog."),
 		info.Task("release", "description", "Explain the changes made for a release."),
+		lint.Task("coverage", "changelog", "Check that the changelog accounts for commits since the last release."),
+		lint.Task("fix", "changelog", "Fix changelog problems that can be fixed automatically."),
 		lint.Task("project-files", "changelog", "Check changelog for correctness."),
 		release.Task("mint", "changelog", "Check and prepare changelog for release."),
 		release.Task("publish", "changelog", "Capture changelog data to prepare for release.", "mint"),
@@ -43,13 +52,23 @@ func (p *Plugin) Prepare(
 	task string,
 ) (plugin.Task, error) {
 	switch task {
+	case "/generate/changes/changelog":
+		return &GenerateChangelogTask{}, nil
+	case "/generate/entry/changelog":
+		return &AddChangelogTask{}, nil
+	case "/lint/coverage/changelog":
+		return &LintCoverageChangelogTask{}, nil
+	case "/lint/fix/changelog":
+		return &LintFixChangelogTask{}, nil
 	case "/lint/project-files/changelog":
 		return &LintChangelogTask{}, nil
 	case "/info/release/description":
 		return &InfoChangelogTask{}, nil
 	case "/release/mint/changelog":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleaseMintTask{}, nil
 	case "/release/publish/changelog":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleasePublishTask{}, nil
 	}
 	return nil, plugin.ErrUnsupportedTask
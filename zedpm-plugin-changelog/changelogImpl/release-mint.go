@@ -1,12 +1,12 @@
 package changelogImpl
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"fmt"
-	"os"
+	"io"
 
-	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/changes"
 	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/plugin"
 )
@@ -18,53 +18,42 @@ type ReleaseMintTask struct {
 
 // FixupChangelog alters the changelog to prepare it for release.
 func (s *ReleaseMintTask) FixupChangelog(ctx context.Context) error {
-	r, err := os.Open(GetPropertyChangelogFile(ctx))
+	chgFormat, err := changes.GetPropertyChangelogFormat(ctx)
 	if err != nil {
-		return format.WrapErr(err, "unable to open %s", GetPropertyChangelogFile(ctx))
+		return err
 	}
 
-	newChangelog := GetPropertyChangelogFile(ctx) + ".new"
-
-	w, err := os.Create(newChangelog)
+	version, err := goals.GetPropertyReleaseVersion(ctx)
 	if err != nil {
-		return format.WrapErr(err, "unable to create %s", newChangelog)
+		return err
 	}
 
-	plugin.ForCleanup(ctx, func() { _ = os.Remove(newChangelog) })
-
-	sc := bufio.NewScanner(r)
-	for sc.Scan() {
-		line := sc.Text()
-		if line == "WIP" || line == "WIP  TBD" {
-			version, err := goals.GetPropertyReleaseVersion(ctx)
-			if err != nil {
-				return err
-			}
-
-			todayTime := goals.GetPropertyReleaseDate(ctx)
+	rollUp := changes.GetPropertyChangelogRollUp(ctx) && !goals.IsPreRelease(version)
+	rollUpper, canRollUp := chgFormat.(changes.RollUpper)
+	if rollUp && !canRollUp {
+		return fmt.Errorf("the %q changelog format does not support rolling up pre-releases",
+			plugin.GetString(ctx, changes.PropertyChangelogFormat))
+	}
 
-			today := todayTime.Format("2006-01-02")
-			_, _ = fmt.Fprintf(w, "v%s  %s\n", version, today)
-		} else {
-			_, _ = fmt.Fprintln(w, line)
+	err = RewriteChangelog(ctx, func(r io.Reader, w io.Writer) error {
+		if !rollUp {
+			return chgFormat.FixupForRelease(r, w, version, goals.GetPropertyReleaseDate(ctx))
 		}
-	}
 
-	_ = r.Close()
-	err = w.Close()
-	if err != nil {
-		return format.WrapErr(err, "unable to close %s", newChangelog)
-	}
+		fixed := &bytes.Buffer{}
+		err := chgFormat.FixupForRelease(r, fixed, version, goals.GetPropertyReleaseDate(ctx))
+		if err != nil {
+			return err
+		}
 
-	err = os.Rename(newChangelog, GetPropertyChangelogFile(ctx))
+		return rollUpper.RollUp(fixed, w, version)
+	})
 	if err != nil {
-		return format.WrapErr(err, "unable to overwrite %s with %s", GetPropertyChangelogFile(ctx), newChangelog)
+		return err
 	}
 
-	plugin.ToAdd(ctx, GetPropertyChangelogFile(ctx))
-
 	plugin.Logger(ctx,
-		"changelog", GetPropertyChangelogFile(ctx),
+		"changelog", changes.GetPropertyChangelogFile(ctx),
 	).Info("Applied changes to changelog to fixup for release.")
 
 	return nil
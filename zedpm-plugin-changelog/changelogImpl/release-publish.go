@@ -5,7 +5,6 @@ import (
 	"io"
 
 	"github.com/zostay/zedpm/format"
-	"github.com/zostay/zedpm/pkg/changes"
 	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/plugin"
 )
@@ -24,9 +23,7 @@ func (f *ReleasePublishTask) CaptureChangesInfo(ctx context.Context) error {
 		return err
 	}
 
-	vstring := "v" + version
-	changelog := GetPropertyChangelogFile(ctx)
-	cr, err := changes.ExtractSection(changelog, vstring)
+	cr, err := ExtractSection(ctx, version)
 	if err != nil {
 		return format.WrapErr(err, "unable to get log of changes")
 	}
@@ -0,0 +1,88 @@
+package gitImpl
+
+import (
+	"context"
+
+	"github.com/zostay/zedpm/format"
+	zGit "github.com/zostay/zedpm/pkg/git"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// InfoGitTask implements the /info/vcs/git task, which describes the state of
+// the git repository.
+type InfoGitTask struct {
+	plugin.TaskBoilerplate
+	zGit.Git
+}
+
+// Setup opens the git repository. No remote is needed to describe it.
+func (t *InfoGitTask) Setup(ctx context.Context) error {
+	return t.SetupGitHistory(ctx)
+}
+
+// DescribeRepository exports git.head, git.branch, git.lastTag,
+// git.commitsSinceTag, git.isDirty, and git.describe. The last tag is the release
+// tag nearest to HEAD among its ancestors, as chosen by git describe.
+func (t *InfoGitTask) DescribeRepository(ctx context.Context) error {
+	headRef, err := t.Backend().Head()
+	if err != nil {
+		return format.WrapErr(err, "unable to find HEAD")
+	}
+
+	branch := ""
+	if headRef.Name().IsBranch() {
+		branch = headRef.Name().Short()
+	}
+
+	tag, commitsSinceTag, err := t.NearestReleaseTag(goals.ReleaseTagPrefix(ctx))
+	if err != nil {
+		return format.WrapErr(err, "unable to find the nearest release tag")
+	}
+
+	lastTag := ""
+	if tag != nil {
+		lastTag = tag.Name
+	}
+
+	dirtyFiles, err := t.DirtyFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	head := headRef.Hash().String()
+	dirty := len(dirtyFiles) > 0
+
+	plugin.AtomicProperties(ctx, func(kv storage.KV) {
+		kv.Set(zGit.PropertyGitHead, head)
+		kv.Set(zGit.PropertyGitBranch, branch)
+		kv.Set(zGit.PropertyGitLastTag, lastTag)
+		kv.Set(zGit.PropertyGitCommitsSinceTag, commitsSinceTag)
+		kv.Set(zGit.PropertyGitDirty, dirty)
+		kv.Set(zGit.PropertyGitDescribe, zGit.Describe(lastTag, commitsSinceTag, head, dirty))
+	})
+
+	for _, key := range []string{
+		zGit.PropertyGitHead,
+		zGit.PropertyGitBranch,
+		zGit.PropertyGitLastTag,
+		zGit.PropertyGitCommitsSinceTag,
+		zGit.PropertyGitDirty,
+		zGit.PropertyGitDescribe,
+	} {
+		goals.ExportPropertyName(ctx, key)
+	}
+
+	return nil
+}
+
+// Run prepares the DescribeRepository operation.
+func (t *InfoGitTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(t.DescribeRepository),
+		},
+	}, nil
+}
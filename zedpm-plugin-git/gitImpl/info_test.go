@@ -0,0 +1,116 @@
+package gitImpl
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	zGit "github.com/zostay/zedpm/pkg/git"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// initRepo creates a git repository without a remote, tagged v1.0.0 on its
+// first commit and followed by a commit for each of the given messages. It
+// changes into the repository for the rest of the test.
+func initRepo(t *testing.T, messages ...string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run("init", "-q", "-b", "master")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+	run("config", "commit.gpgsign", "false")
+	run("config", "tag.gpgsign", "false")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	run("add", "a.txt")
+	run("commit", "-q", "-m", "first")
+	run("tag", "v1.0.0")
+	for _, message := range messages {
+		run("commit", "-q", "--allow-empty", "-m", message)
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestInfoGitTaskWithoutRemote(t *testing.T) {
+	initRepo(t, "second")
+
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, storage.New()))
+
+	task := &InfoGitTask{}
+	require.NoError(t, task.Setup(ctx))
+	require.NoError(t, task.DescribeRepository(ctx))
+
+	assert.Equal(t, "master", plugin.GetString(ctx, zGit.PropertyGitBranch))
+	assert.Equal(t, "v1.0.0", plugin.GetString(ctx, zGit.PropertyGitLastTag))
+	assert.Equal(t, 1, plugin.GetInt(ctx, zGit.PropertyGitCommitsSinceTag))
+	assert.False(t, plugin.GetBool(ctx, zGit.PropertyGitDirty))
+}
+
+func TestInfoGitTaskNearestTag(t *testing.T) {
+	initRepo(t, "second")
+
+	run := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	// a newer release on another branch is not an ancestor of HEAD
+	run("checkout", "-q", "-b", "next")
+	run("commit", "-q", "--allow-empty", "-m", "next")
+	run("tag", "v2.0.0")
+	run("checkout", "-q", "master")
+
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, storage.New()))
+
+	task := &InfoGitTask{}
+	require.NoError(t, task.Setup(ctx))
+	require.NoError(t, task.DescribeRepository(ctx))
+
+	out, err := exec.Command("git", "describe", "--tags").Output()
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.0.0", plugin.GetString(ctx, zGit.PropertyGitLastTag))
+	assert.Equal(t, 1, plugin.GetInt(ctx, zGit.PropertyGitCommitsSinceTag))
+	assert.Equal(t, strings.TrimSpace(string(out)), plugin.GetString(ctx, zGit.PropertyGitDescribe))
+
+	run("tag", "v1.0.1")
+	require.NoError(t, task.DescribeRepository(ctx))
+
+	assert.Equal(t, "v1.0.1", plugin.GetString(ctx, zGit.PropertyGitLastTag))
+	assert.Equal(t, 0, plugin.GetInt(ctx, zGit.PropertyGitCommitsSinceTag))
+	assert.Equal(t, "v1.0.1", plugin.GetString(ctx, zGit.PropertyGitDescribe))
+}
+
+func TestInfoGitTaskDirtyIgnore(t *testing.T) {
+	initRepo(t)
+	require.NoError(t, os.WriteFile("scratch.tmp", []byte("scratch\n"), 0o644))
+
+	kv := storage.New()
+	kv.Set(zGit.PropertyGitDirtyIgnore, "*.tmp")
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, kv))
+
+	task := &InfoGitTask{}
+	require.NoError(t, task.Setup(ctx))
+	require.NoError(t, task.DescribeRepository(ctx))
+
+	assert.False(t, plugin.GetBool(ctx, zGit.PropertyGitDirty))
+	assert.Equal(t, "*.tmp", plugin.GetString(ctx, zGit.PropertyGitDirtyIgnore))
+}
@@ -13,12 +13,15 @@ var _ plugin.Interface = &Plugin{}
 // Plugin implements the plugin.Interface for performing tasks related to git.
 type Plugin struct{}
 
-// Implements provides task descriptions for /release/mint/git and
-// /release/publish/git tasks.
+// Implements provides task descriptions for /info/vcs/git,
+// /release/version/git, /release/mint/git, and /release/publish/git tasks.
 func (p *Plugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
+	info := goals.DescribeInfo()
 	release := goals.DescribeRelease()
 	return []plugin.TaskDescription{
-		release.Task("mint", "git", "Verify work directory is clean and push a release branch."),
+		info.Task("vcs", "git", "Describe the state of the git repository."),
+		release.Task("version", "git", "Compute the next release version."),
+		release.Task("mint", "git", "Verify work directory is clean and push a release branch.", "version"),
 		release.Task("publish", "git", "Push a release tag.", "mint"),
 	}, nil
 }
@@ -34,9 +37,19 @@ func (p *Plugin) Prepare(
 	task string,
 ) (plugin.Task, error) {
 	switch task {
+	case "/info/vcs/git":
+		return &InfoGitTask{}, nil
+	case "/release/version/git":
+		t := &ReleaseVersionTask{}
+		if err := t.SuggestVersion(ctx); err != nil {
+			return nil, err
+		}
+		return t, nil
 	case "/release/mint/git":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleaseMintTask{}, nil
 	case "/release/publish/git":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleasePublishTask{}, nil
 	}
 	return nil, plugin.ErrUnsupportedTask
@@ -3,9 +3,8 @@ package gitImpl
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 
 	"github.com/zostay/zedpm/format"
@@ -24,26 +23,6 @@ func (s *ReleaseMintTask) Setup(ctx context.Context) error {
 	return s.SetupGitRepo(ctx)
 }
 
-// IsDirty returns true if we consider the tree dirty. We do not consider
-// Untracked to dirty the directory and we also ignore some filenames that are
-// in the global .gitignore and not in the local .gitignore.
-func IsDirty(status git.Status) bool {
-	for fn, fstat := range status {
-		if _, ignorable := zGit.IgnoreStatus[fn]; ignorable {
-			continue
-		}
-
-		if fstat.Worktree != git.Unmodified && fstat.Worktree != git.Untracked {
-			return true
-		}
-
-		if fstat.Staging != git.Unmodified && fstat.Staging != git.Untracked {
-			return true
-		}
-	}
-	return false
-}
-
 // CheckGitCleanliness ensures that the current git repository is clean and that
 // we are on the correct branch from which to trigger a release.
 func (s *ReleaseMintTask) CheckGitCleanliness(ctx context.Context) error {
@@ -53,7 +32,7 @@ func (s *ReleaseMintTask) CheckGitCleanliness(ctx context.Context) error {
 	)
 	logger.Info("Finding the HEAD reference")
 
-	headRef, err := s.Repository().Head()
+	headRef, err := s.Backend().Head()
 	if err != nil {
 		return format.WrapErr(err, "unable to find HEAD")
 	}
@@ -62,10 +41,15 @@ func (s *ReleaseMintTask) CheckGitCleanliness(ctx context.Context) error {
 		return fmt.Errorf("you must checkout %s to release", zGit.TargetBranch(ctx))
 	}
 
+	if err := zGit.CheckPreReleaseBranch(ctx); err != nil {
+		return err
+	}
+
 	logger = logger.With("headRef", headRef.String())
 	logger.Info("Finding the remote master reference")
 
-	remoteRefs, err := s.Remote().List(&git.ListOptions{})
+	remote := s.Remote().Name
+	remoteRefs, err := s.Backend().ListRemote(remote)
 	if err != nil {
 		return format.WrapErr(err, "unable to list remote git references")
 	}
@@ -78,7 +62,11 @@ func (s *ReleaseMintTask) CheckGitCleanliness(ctx context.Context) error {
 		}
 	}
 
-	logger = logger.With("masterRef", masterRef.String())
+	if masterRef == nil {
+		return fmt.Errorf("remote %s has no %s branch, you need to push", remote, zGit.TargetBranch(ctx))
+	}
+
+	logger = logger.With("masterRef", masterRef.String(), "remote", remote)
 	logger.Info("Checking if local master reference matches remote")
 
 	if headRef.Hash() != masterRef.Hash() {
@@ -87,14 +75,18 @@ func (s *ReleaseMintTask) CheckGitCleanliness(ctx context.Context) error {
 
 	logger.Info("Checking that the local copy is clean")
 
-	stat, err := s.Worktree().Status()
+	if GetPropertyGitIgnoreDirty(ctx) {
+		logger.Info("Skipping the check because git.ignoreDirty is set")
+		return nil
+	}
+
+	dirty, err := s.DirtyFiles(ctx)
 	if err != nil {
-		return format.WrapErr(err, "unable to check working copy status")
+		return err
 	}
 
-	ignoreDirty := GetPropertyGitIgnoreDirty(ctx)
-	if !ignoreDirty && IsDirty(stat) {
-		return fmt.Errorf("your working copy is dirty")
+	if len(dirty) > 0 {
+		return fmt.Errorf("your working copy is dirty, these files have changes: %s", strings.Join(dirty, ", "))
 	}
 
 	logger.Info("Git working tree is clean for release")
@@ -109,7 +101,7 @@ func (s *ReleaseMintTask) Check(ctx context.Context) error {
 
 // MakeReleaseBranch creates the branch that will be used to manage the release.
 func (s *ReleaseMintTask) MakeReleaseBranch(ctx context.Context) error {
-	headRef, err := s.Repository().Head()
+	headRef, err := s.Backend().Head()
 	if err != nil {
 		return format.WrapErr(err, "unable to retrieve the HEAD ref")
 	}
@@ -120,7 +112,7 @@ func (s *ReleaseMintTask) MakeReleaseBranch(ctx context.Context) error {
 	}
 
 	branch, _ := zGit.GetPropertyGitReleaseBranch(ctx)
-	err = s.Worktree().Checkout(&git.CheckoutOptions{
+	err = s.Backend().Checkout(&zGit.CheckoutOptions{
 		Hash:   headRef.Hash(),
 		Branch: branchRefName,
 		Create: true,
@@ -130,10 +122,10 @@ func (s *ReleaseMintTask) MakeReleaseBranch(ctx context.Context) error {
 	}
 
 	plugin.ForCleanup(ctx, func() {
-		_ = s.Repository().Storer.RemoveReference(branchRefName)
+		_ = s.Backend().DeleteRef(branchRefName)
 	})
 	plugin.ForCleanup(ctx, func() {
-		_ = s.Worktree().Checkout(&git.CheckoutOptions{
+		_ = s.Backend().Checkout(&zGit.CheckoutOptions{
 			Branch: zGit.TargetBranchRefName(ctx),
 		})
 	})
@@ -164,7 +156,7 @@ func (s *ReleaseMintTask) AddAndCommit(ctx context.Context) error {
 	logger := plugin.Logger(ctx)
 	addedFiles := plugin.ListAdded(ctx)
 	for _, fn := range addedFiles {
-		_, err := s.Worktree().Add(fn)
+		err := s.Backend().Add(fn)
 		if err != nil {
 			return format.WrapErr(err, "error adding file %s to git", fn)
 		}
@@ -174,11 +166,20 @@ func (s *ReleaseMintTask) AddAndCommit(ctx context.Context) error {
 
 	version := plugin.GetString(ctx, "release.version")
 	msg := "releng: v" + version
-	_, err := s.Worktree().Commit("releng: v"+version, &git.CommitOptions{})
+	hash, err := s.Backend().Commit(msg, s.Signing())
 	if err != nil {
 		return format.WrapErr(err, "error committing changes to git")
 	}
 
+	if s.Signing() != nil {
+		err := s.Backend().VerifyCommit(hash, s.Signing())
+		if err != nil {
+			return format.WrapErr(err, "unable to verify the signed release commit")
+		}
+
+		logger.Info("Verified signature of release commit", "commit", hash)
+	}
+
 	plugin.Logger(ctx,
 		"count", len(addedFiles),
 		"version", version,
@@ -188,34 +189,15 @@ func (s *ReleaseMintTask) AddAndCommit(ctx context.Context) error {
 	return nil
 }
 
-// PushReleaseBranch pushes the release branch to github for release testing.
+// PushReleaseBranch pushes the release branch to each of the push remotes for
+// release testing.
 func (s *ReleaseMintTask) PushReleaseBranch(ctx context.Context) error {
 	branchRefSpec, err := zGit.ReleaseBranchRefSpec(ctx)
 	if err != nil {
 		return format.WrapErr(err, "unable to determine the ref spec")
 	}
 
-	err = s.Repository().Push(&git.PushOptions{
-		RemoteName: "origin",
-		RefSpecs:   []config.RefSpec{branchRefSpec},
-	})
-	if err != nil {
-		return format.WrapErr(err, "error pushing changes to github branch %q", branchRefSpec.String())
-	}
-
-	plugin.ForCleanup(ctx, func() {
-		_ = s.Remote().Push(&git.PushOptions{
-			RemoteName: "origin",
-			RefSpecs:   []config.RefSpec{branchRefSpec},
-			Prune:      true,
-		})
-	})
-
-	plugin.Logger(ctx,
-		"branchRefSpec", branchRefSpec,
-	).Info("Pushed release branch to remote repository")
-
-	return nil
+	return s.PushToRemotes(ctx, branchRefSpec)
 }
 
 // End sets up the AddAndCommit and PushReleaseBranch operations.
@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 
 	"github.com/zostay/zedpm/format"
 	zGit "github.com/zostay/zedpm/pkg/git"
@@ -25,14 +24,19 @@ func (f *ReleasePublishTask) Setup(ctx context.Context) error {
 
 // TagRelease creates and pushes a tag for the newly merged release on master.
 func (f *ReleasePublishTask) TagRelease(ctx context.Context) error {
-	err := f.Worktree().Checkout(&git.CheckoutOptions{
+	err := zGit.CheckPreReleaseBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = f.Backend().Checkout(&zGit.CheckoutOptions{
 		Branch: zGit.TargetBranchRefName(ctx),
 	})
 	if err != nil {
 		return format.WrapErr(err, "unable to switch to %s branch", zGit.TargetBranch(ctx))
 	}
 
-	headRef, err := f.Repository().Head()
+	headRef, err := f.Backend().Head()
 	if err != nil {
 		return format.WrapErr(err, "unable to get HEAD ref of %s branch", zGit.TargetBranch(ctx))
 	}
@@ -43,36 +47,32 @@ func (f *ReleasePublishTask) TagRelease(ctx context.Context) error {
 	}
 
 	head := headRef.Hash()
-	_, err = f.Repository().CreateTag(tag, head, &git.CreateTagOptions{
-		Message: fmt.Sprintf("Release tag %q", tag),
-	})
+	err = f.Backend().CreateTag(tag, head, fmt.Sprintf("Release tag %q", tag), f.Signing())
 	if err != nil {
 		return format.WrapErr(err, "unable to tag release %q", tag)
 	}
 
-	plugin.ForCleanup(ctx, func() { _ = f.Repository().DeleteTag(tag) })
+	plugin.ForCleanup(ctx, func() { _ = f.Backend().DeleteRef(plumbing.NewTagReferenceName(tag)) })
+
+	if f.Signing() != nil {
+		err := f.Backend().VerifyTag(tag, f.Signing())
+		if err != nil {
+			return format.WrapErr(err, "unable to verify the signed release tag %q", tag)
+		}
+
+		plugin.Logger(ctx, "tag", tag).Info("Verified signature of release tag")
+	}
 
 	tagRefSpec, err := zGit.ReleaseTagRefSpec(ctx)
 	if err != nil {
 		return format.WrapErr(err, "unable to determine release tag ref spec")
 	}
 
-	err = f.Repository().Push(&git.PushOptions{
-		RemoteName: "origin",
-		RefSpecs:   []config.RefSpec{tagRefSpec},
-	})
+	err = f.PushToRemotes(ctx, tagRefSpec)
 	if err != nil {
-		return format.WrapErr(err, "unable to push tag %q to origin", tag)
+		return format.WrapErr(err, "unable to push tag %q", tag)
 	}
 
-	plugin.ForCleanup(ctx, func() {
-		_ = f.Remote().Push(&git.PushOptions{
-			RemoteName: "origin",
-			RefSpecs:   []config.RefSpec{tagRefSpec},
-			Prune:      true,
-		})
-	})
-
 	plugin.Logger(ctx,
 		"headRef", headRef,
 		"tag", tag,
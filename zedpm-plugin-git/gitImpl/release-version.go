@@ -0,0 +1,149 @@
+package gitImpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/changes"
+	"github.com/zostay/zedpm/pkg/conventional"
+	zGit "github.com/zostay/zedpm/pkg/git"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/version"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleaseVersionTask implements the /release/version/git task, which computes
+// the next release version.
+type ReleaseVersionTask struct {
+	plugin.TaskBoilerplate
+	zGit.Git
+
+	previous *semver.Version
+	next     *semver.Version
+}
+
+// LastRelease finds the version of the last release. This is the highest
+// version tagged with the release tag prefix. If there are no such tags, the
+// version of the latest heading in the changelog is used. If neither is found,
+// the last release is taken to be 0.0.0. The hash of the tagged commit is
+// returned if the version was found in a tag.
+func (s *ReleaseVersionTask) LastRelease(ctx context.Context) (*semver.Version, plumbing.Hash, error) {
+	tag, err := s.LatestReleaseTag(goals.ReleaseTagPrefix(ctx))
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+
+	if tag != nil {
+		return tag.Version, tag.Commit, nil
+	}
+
+	v, err := changes.ReadLatestVersion(ctx)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, plumbing.ZeroHash, format.WrapErr(err, "unable to read last version from changelog")
+	}
+
+	if v != nil {
+		return v, plumbing.ZeroHash, nil
+	}
+
+	return &semver.Version{}, plumbing.ZeroHash, nil
+}
+
+// NextVersion computes the next version from the last release. If release.bump
+// is set, it names the part of the version to increment. Otherwise, the bump
+// is inferred from the conventional commits made since the last release tag.
+// It returns nil if no bump is requested and none can be inferred.
+func (s *ReleaseVersionTask) NextVersion(ctx context.Context) (*semver.Version, error) {
+	prev, since, err := s.LastRelease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.previous = prev
+
+	var bump version.Bump
+	if bumpName := goals.GetPropertyReleaseBump(ctx); bumpName != "" {
+		bump, err = version.ParseBump(bumpName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		commits, err := s.CommitsSince(since)
+		if err != nil {
+			return nil, err
+		}
+
+		ccs := make([]*conventional.Commit, 0, len(commits))
+		for _, c := range commits {
+			if cc := conventional.Parse(c.Message); cc != nil {
+				ccs = append(ccs, cc)
+			}
+		}
+
+		bump = version.Infer(*prev, ccs)
+	}
+
+	if bump == version.BumpNone {
+		return nil, nil
+	}
+
+	next := version.Next(*prev, bump)
+	return &next, nil
+}
+
+// SuggestVersion computes the next version and suggests it as the default
+// value of release.version. This is called while the task is prepared, so the
+// suggestion is in place when the master process checks that every required
+// property is set before the first phase runs. Without it, release.version
+// could never be derived from release.bump or from conventional commits.
+func (s *ReleaseVersionTask) SuggestVersion(ctx context.Context) error {
+	if err := s.SetupGitHistory(ctx); err != nil {
+		return err
+	}
+
+	next, err := s.NextVersion(ctx)
+	if err != nil {
+		return format.WrapErr(err, "unable to compute the next release version")
+	}
+
+	s.next = next
+	if next != nil {
+		plugin.SuggestDefault(ctx, goals.PropertyReleaseVersion, next.String())
+	}
+
+	return nil
+}
+
+// Check sets release.version to the computed version, if it has not been set,
+// and verifies that the release version follows the last release.
+func (s *ReleaseVersionTask) Check(ctx context.Context) error {
+	if !plugin.IsSet(ctx, goals.PropertyReleaseVersion) && s.next != nil {
+		goals.SetPropertyReleaseVersion(ctx, s.next.String())
+	}
+
+	vstring, err := goals.GetPropertyReleaseVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	v, err := semver.NewVersion(vstring)
+	if err != nil {
+		return format.WrapErr(err, "release version %q is not a semantic version", vstring)
+	}
+
+	if !s.previous.LessThan(*v) {
+		return fmt.Errorf("release version %s must be greater than the last release %s", v, s.previous)
+	}
+
+	plugin.Logger(ctx,
+		"previous", s.previous.String(),
+		"version", v.String(),
+	).Info("Determined the release version")
+
+	return nil
+}
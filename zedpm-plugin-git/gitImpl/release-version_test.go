@@ -0,0 +1,33 @@
+package gitImpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+func TestSuggestVersionWithoutRemote(t *testing.T) {
+	initRepo(t, "docs: explain things", "fix: a thing")
+
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, storage.New()))
+
+	task := &ReleaseVersionTask{}
+	require.NoError(t, task.SuggestVersion(ctx))
+	assert.Equal(t, "1.0.1", plugin.GetString(ctx, plugin.DefaultPrefix+goals.PropertyReleaseVersion))
+}
+
+func TestSuggestVersionDocsOnly(t *testing.T) {
+	initRepo(t, "docs: explain things", "chore: tidy up")
+
+	ctx := plugin.InitializeContext(context.Background(), plugin.NewContext(nil, storage.New()))
+
+	task := &ReleaseVersionTask{}
+	require.NoError(t, task.SuggestVersion(ctx))
+	assert.False(t, plugin.IsSet(ctx, plugin.DefaultPrefix+goals.PropertyReleaseVersion))
+}
@@ -0,0 +1,3 @@
+// Package giteaImpl implements the zedpm-plugin-gitea plugin, which works with
+// both Gitea and Forgejo.
+package giteaImpl
@@ -0,0 +1,55 @@
+package giteaImpl
+
+import (
+	"context"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// Verifies that Plugin implements plugin.Interface.
+var _ plugin.Interface = &Plugin{}
+
+// Plugin implements plugin.Interface for handling Gitea-related tasks.
+type Plugin struct{}
+
+// Implements returns the task descriptions for the /release/mint/gitea and
+// /release/publish/gitea tasks.
+func (p *Plugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
+	rel := goals.DescribeRelease()
+	return []plugin.TaskDescription{
+		rel.Task("mint", "gitea", "Create a Gitea pull request."),
+		rel.Task("publish", "gitea", "Merge and publish a Gitea release.", "mint"),
+	}, nil
+}
+
+// Goal returns plugin.ErrUnsupportedGoal.
+func (p *Plugin) Goal(context.Context, string) (plugin.GoalDescription, error) {
+	return nil, plugin.ErrUnsupportedGoal
+}
+
+// Prepare returns the implemented tasks.
+func (p *Plugin) Prepare(
+	ctx context.Context,
+	task string,
+) (plugin.Task, error) {
+	switch task {
+	case "/release/mint/gitea":
+		goals.RequirePropertyReleaseVersion(ctx)
+		return &ReleaseMintTask{}, nil
+	case "/release/publish/gitea":
+		goals.RequirePropertyReleaseVersion(ctx)
+		return &ReleasePublishTask{}, nil
+	}
+	return nil, plugin.ErrUnsupportedTask
+}
+
+// Cancel is a no-op.
+func (p *Plugin) Cancel(ctx context.Context, task plugin.Task) error {
+	return nil
+}
+
+// Complete is a no-op.
+func (p *Plugin) Complete(ctx context.Context, task plugin.Task) error {
+	return nil
+}
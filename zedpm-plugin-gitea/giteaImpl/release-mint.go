@@ -0,0 +1,112 @@
+package giteaImpl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGitea "github.com/zostay/zedpm/pkg/gitea"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleaseMintTask implements the /release/mint/gitea task.
+type ReleaseMintTask struct {
+	plugin.TaskBoilerplate
+	zGitea.Gitea
+}
+
+// Setup configures the Gitea and git clients.
+func (s *ReleaseMintTask) Setup(ctx context.Context) error {
+	return s.SetupGiteaClient(ctx)
+}
+
+// CreatePullRequest creates the pull request on Gitea for monitoring the status
+// checks for release testing. This will also be used to merge the release
+// branch when the checks pass.
+func (s *ReleaseMintTask) CreatePullRequest(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CreatePullRequest")
+	logger.StartAction("CreatePullRequest", "Creating Gitea pull request", "spin")
+
+	owner, project, err := s.OwnerProject(ctx)
+	if err != nil {
+		logger.MarkAction("CreatePullRequest", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger.TickAction("CreatePullRequest")
+
+	branch, err := git.GetPropertyGitReleaseBranch(ctx)
+	if err != nil {
+		logger.MarkAction("CreatePullRequest", log.Fail)
+		return format.WrapErr(err, "failed to get release branch name")
+	}
+
+	logger.TickAction("CreatePullRequest")
+
+	prName, err := zGitea.GetPropertyGiteaReleaseName(ctx)
+	if err != nil {
+		logger.MarkAction("CreatePullRequest", log.Fail)
+		return format.WrapErr(err, "failed to get release name")
+	}
+
+	logger.TickAction("CreatePullRequest")
+
+	body := fmt.Sprintf("Pull request to complete %q of project.", prName)
+	if version, err := goals.GetPropertyReleaseVersion(ctx); err == nil {
+		body = fmt.Sprintf("Pull request to complete release for v%s of project.", version)
+	}
+
+	targetBranch := git.GetPropertyGitTargetBranch(ctx)
+	logger = logger.With(
+		"owner", owner,
+		"project", project,
+		"branch", branch,
+		"targetBranch", targetBranch,
+		"pullRequestName", prName,
+	)
+
+	var pr *zGitea.PullRequest
+	for retries := 3; retries > 0; retries-- {
+		logger.MarkAction("CreatePullRequest", log.Working)
+		pr, err = s.Client().CreatePullRequest(ctx, owner, project, &zGitea.NewPullRequest{
+			Head:  branch,
+			Base:  targetBranch,
+			Title: prName,
+			Body:  body,
+		})
+
+		logger.TickAction("CreatePullRequest")
+
+		if err == nil {
+			break
+		}
+
+		logger.MarkAction("CreatePullRequest", log.Retry)
+
+		<-time.After(5 * time.Second)
+	}
+
+	if err != nil {
+		logger.MarkAction("CreatePullRequest", log.Fail)
+		return format.WrapErr(err, "unable to create pull request")
+	}
+
+	logger.MarkAction("CreatePullRequest", log.Pass)
+	logger.With("pullRequestID", pr.Number, "url", pr.HTMLURL).Info("Created the pull request.")
+
+	return nil
+}
+
+// End configures CreatePullRequest to run.
+func (s *ReleaseMintTask) End(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  80,
+			Action: plugin.OperationFunc(s.CreatePullRequest),
+		},
+	}, nil
+}
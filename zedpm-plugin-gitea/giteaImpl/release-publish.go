@@ -0,0 +1,284 @@
+package giteaImpl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGitea "github.com/zostay/zedpm/pkg/gitea"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleasePublishTask implements the /release/publish/gitea task.
+type ReleasePublishTask struct {
+	plugin.TaskBoilerplate
+	zGitea.Gitea
+
+	artifacts []string
+}
+
+// Setup configures the Gitea and git clients.
+func (f *ReleasePublishTask) Setup(ctx context.Context) error {
+	return f.SetupGiteaClient(ctx)
+}
+
+// errChecksPending is returned by CheckStatus while the status checks have not
+// finished.
+var errChecksPending = fmt.Errorf("status checks are still pending")
+
+// findPullRequest returns the open pull request for the release branch.
+func (f *ReleasePublishTask) findPullRequest(
+	ctx context.Context,
+	owner, project string,
+) (*zGitea.PullRequest, error) {
+	branch, err := git.GetPropertyGitReleaseBranch(ctx)
+	if err != nil {
+		return nil, format.WrapErr(err, "failed to get release branch name")
+	}
+
+	pr, err := f.Client().FindPullRequest(ctx, owner, project, branch)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to list pull requests")
+	}
+
+	if pr == nil {
+		return nil, fmt.Errorf("cannot find pull request for branch %s", branch)
+	}
+
+	return pr, nil
+}
+
+// CheckStatus checks the combined status of the checks on the head of the pull
+// request for the release branch. It returns errChecksPending if the checks
+// have not finished yet. A pull request without any checks is ready to merge.
+func (f *ReleasePublishTask) CheckStatus(ctx context.Context) error {
+	owner, project, err := f.OwnerProject(ctx)
+	if err != nil {
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	pr, err := f.findPullRequest(ctx, owner, project)
+	if err != nil {
+		return err
+	}
+
+	ref := pr.Head.SHA
+	if ref == "" {
+		ref = pr.Head.Ref
+	}
+
+	status, err := f.Client().GetCombinedStatus(ctx, owner, project, ref)
+	if err != nil {
+		return format.WrapErr(err, "unable to get the status of pull request %d", pr.Number)
+	}
+
+	logger := plugin.Logger(ctx, "owner", owner, "project", project, "pullRequestID", pr.Number)
+	if status.TotalCount == 0 {
+		logger.Info("Pull request is ready to merge: no status checks")
+		return nil
+	}
+
+	switch status.State {
+	case zGitea.StatusSuccess:
+		logger.Info("Pull request is ready to merge: all status checks passed")
+		return nil
+	case zGitea.StatusPending:
+		return errChecksPending
+	}
+
+	for _, s := range status.Statuses {
+		if s.State != zGitea.StatusSuccess && s.State != zGitea.StatusPending {
+			return fmt.Errorf("cannot merge release branch because it has not passed check %q", s.Context)
+		}
+	}
+
+	return fmt.Errorf("cannot merge release branch because its status checks are in state %q", status.State)
+}
+
+// Check finds the artifacts to attach to the release, failing if any pattern
+// in release.artifacts matches no files, so that nothing is merged when the
+// release cannot be completed. It then waits for the status checks of the pull
+// request to finish, checking every gitea.status.interval until
+// gitea.status.timeout has elapsed. It fails if any check does not succeed.
+func (f *ReleasePublishTask) Check(ctx context.Context) error {
+	var err error
+	f.artifacts, err = goals.GetPropertyReleaseArtifacts(ctx)
+	if err != nil {
+		return format.WrapErr(err, "failed to find release artifacts")
+	}
+
+	logger := plugin.Logger(ctx, "operation", "CheckStatus")
+	logger.StartAction("CheckStatus", "Waiting for Gitea status checks to pass", "spin")
+
+	ctx, cancel := context.WithTimeout(ctx, zGitea.GetPropertyGiteaStatusTimeout(ctx))
+	defer cancel()
+
+	interval := zGitea.GetPropertyGiteaStatusInterval(ctx)
+	for {
+		err := f.CheckStatus(ctx)
+		if err == nil {
+			logger.MarkAction("CheckStatus", log.Pass)
+			return nil
+		}
+
+		if err != errChecksPending {
+			logger.MarkAction("CheckStatus", log.Fail)
+			return err
+		}
+
+		logger.TickAction("CheckStatus")
+
+		select {
+		case <-ctx.Done():
+			logger.MarkAction("CheckStatus", log.Fail)
+			return format.WrapErr(ctx.Err(), "gave up waiting for the status checks to pass")
+		case <-time.After(interval):
+		}
+	}
+}
+
+// MergePullRequest merges the pull request into the target branch.
+func (f *ReleasePublishTask) MergePullRequest(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "MergePullRequest")
+	logger.StartAction("MergePullRequest", "Merging pull request", "spin")
+
+	owner, project, err := f.OwnerProject(ctx)
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger = logger.With("owner", owner, "project", project)
+	logger.TickAction("MergePullRequest")
+
+	pr, err := f.findPullRequest(ctx, owner, project)
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return err
+	}
+
+	logger = logger.With("pullRequestID", pr.Number)
+	logger.TickAction("MergePullRequest")
+
+	err = f.Client().MergePullRequest(ctx, owner, project, pr.Number, "Merging release branch.")
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return format.WrapErr(err, "unable to merge pull request %d", pr.Number)
+	}
+
+	logger.MarkAction("MergePullRequest", log.Pass)
+	logger.Info("Merged the pull request into the target branch.")
+
+	return nil
+}
+
+// CreateRelease creates a release on Gitea for the release tag, described by
+// the changelog, and attaches the artifacts found by Check.
+func (f *ReleasePublishTask) CreateRelease(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CreateRelease")
+	logger.StartAction("CreateRelease", "Creating a Gitea release", "spin")
+
+	owner, project, err := f.OwnerProject(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger = logger.With("owner", owner, "project", project)
+	logger.TickAction("CreateRelease")
+
+	tag, err := git.GetPropertyGitReleaseTag(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to get release tag name")
+	}
+
+	logger = logger.With("tag", tag)
+	logger.TickAction("CreateRelease")
+
+	releaseName, err := zGitea.GetPropertyGiteaReleaseName(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to get release name")
+	}
+
+	logger = logger.With("releaseName", releaseName)
+	logger.TickAction("CreateRelease")
+
+	prerelease := goals.GetPropertyReleasePreRelease(ctx)
+	logger = logger.With("prerelease", prerelease)
+
+	rel, err := f.Client().CreateRelease(ctx, owner, project, &zGitea.Release{
+		TagName:         tag,
+		TargetCommitish: git.GetPropertyGitTargetBranch(ctx),
+		Name:            releaseName,
+		Body:            goals.GetPropertyReleaseDescription(ctx),
+		Prerelease:      prerelease,
+	})
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to create release %q", releaseName)
+	}
+
+	for _, artifact := range f.artifacts {
+		logger.TickAction("CreateRelease")
+
+		err := f.uploadAsset(ctx, owner, project, rel.ID, artifact)
+		if err != nil {
+			logger.MarkAction("CreateRelease", log.Fail)
+			return err
+		}
+
+		logger.With("artifact", artifact).Info("Attached artifact to the release.")
+	}
+
+	logger.MarkAction("CreateRelease", log.Pass)
+
+	return nil
+}
+
+// uploadAsset attaches the file to the release and checks that all of it was
+// received.
+func (f *ReleasePublishTask) uploadAsset(
+	ctx context.Context,
+	owner, project string,
+	releaseID int64,
+	artifact string,
+) error {
+	info, err := os.Stat(artifact)
+	if err != nil {
+		return format.WrapErr(err, "unable to read release artifact %q", artifact)
+	}
+
+	att, err := f.Client().UploadReleaseAsset(ctx, owner, project, releaseID, artifact)
+	if err != nil {
+		return format.WrapErr(err, "failed to upload release artifact %q", artifact)
+	}
+
+	if att.Name != filepath.Base(artifact) || att.Size != info.Size() {
+		return fmt.Errorf("uploaded release artifact %q does not match: got %q with %d bytes, expected %d bytes",
+			artifact, att.Name, att.Size, info.Size())
+	}
+
+	return nil
+}
+
+// End configures MergePullRequest and CreateRelease to run.
+func (f *ReleasePublishTask) End(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  60,
+			Action: plugin.OperationFunc(f.MergePullRequest),
+		},
+		{
+			Order:  80,
+			Action: plugin.OperationFunc(f.CreateRelease),
+		},
+	}, nil
+}
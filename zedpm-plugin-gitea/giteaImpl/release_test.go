@@ -0,0 +1,190 @@
+package giteaImpl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/forgetest"
+	zGitea "github.com/zostay/zedpm/pkg/gitea"
+	"github.com/zostay/zedpm/pkg/goals"
+)
+
+// fakeGitea is an httptest stand-in for the parts of the Gitea API used by the
+// release tasks.
+type fakeGitea struct {
+	lock     sync.Mutex
+	requests []string
+	pulls    []map[string]any
+	statuses []string
+	merged   bool
+	releases []map[string]any
+	assets   map[string]string
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	const repo = "/api/v1/repos/zostay/zedpm"
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, repo)
+	f.requests = append(f.requests, route)
+
+	if r.Header.Get("Authorization") != "token secret" {
+		forgetest.Reply(w, http.StatusUnauthorized, map[string]any{"message": "token is required"})
+		return
+	}
+
+	switch route {
+	case "POST /pulls":
+		f.pulls = append(f.pulls, forgetest.Decode(r))
+		forgetest.Reply(w, http.StatusCreated, f.pull(len(f.pulls)))
+	case "GET /pulls":
+		pulls := make([]any, len(f.pulls))
+		for i := range f.pulls {
+			pulls[i] = f.pull(i + 1)
+		}
+		forgetest.Reply(w, http.StatusOK, pulls)
+	case "GET /commits/abc123/status":
+		state := f.statuses[0]
+		if len(f.statuses) > 1 {
+			f.statuses = f.statuses[1:]
+		}
+		forgetest.Reply(w, http.StatusOK, map[string]any{
+			"state":       state,
+			"total_count": 1,
+			"statuses":    []any{map[string]any{"context": "ci/test", "status": state}},
+		})
+	case "POST /pulls/1/merge":
+		f.merged = forgetest.Decode(r)["Do"] == "merge"
+		w.WriteHeader(http.StatusOK)
+	case "POST /releases":
+		rel := forgetest.Decode(r)
+		rel["id"] = 5
+		f.releases = append(f.releases, rel)
+		forgetest.Reply(w, http.StatusCreated, rel)
+	case "POST /releases/5/assets":
+		file, _, err := r.FormFile("attachment")
+		if err != nil {
+			forgetest.Reply(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+			return
+		}
+		data, _ := io.ReadAll(file)
+		name := r.URL.Query().Get("name")
+		f.assets[name] = string(data)
+		forgetest.Reply(w, http.StatusCreated, map[string]any{"id": len(f.assets), "name": name, "size": len(data)})
+	default:
+		forgetest.Reply(w, http.StatusNotFound, map[string]any{"message": "not found"})
+	}
+}
+
+// pull returns the API representation of the numbered pull request.
+func (f *fakeGitea) pull(number int) map[string]any {
+	pr := f.pulls[number-1]
+	return map[string]any{
+		"number": number,
+		"title":  pr["title"],
+		"state":  "open",
+		"head":   map[string]any{"ref": pr["head"], "sha": "abc123"},
+		"base":   map[string]any{"ref": pr["base"]},
+	}
+}
+
+// setup starts the Gitea stand-in and returns it with a plugin context
+// configured to use it.
+func setup(t *testing.T, props map[string]string) (*fakeGitea, string, context.Context) {
+	t.Helper()
+
+	t.Setenv("GITEA_TOKEN", "secret")
+
+	fake := &fakeGitea{assets: map[string]string{}}
+	baseURL := forgetest.NewServer(t, fake)
+
+	dir := forgetest.InitRepo(t, "https://127.0.0.1/zostay/zedpm.git", "master", "initial")
+
+	return fake, dir, forgetest.Context(map[string]string{
+		zGitea.PropertyGiteaBaseURL:        baseURL,
+		zGitea.PropertyGiteaStatusInterval: "10ms",
+		goals.PropertyReleaseVersion:       "1.2.3",
+	}, props)
+}
+
+func TestRelease(t *testing.T) {
+	fake, dir, ctx := setup(t, map[string]string{
+		goals.PropertyReleaseDescription: "Stuff.",
+		goals.PropertyReleaseArtifacts:   "dist/*",
+	})
+	fake.statuses = []string{"pending", "success"}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "dist"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dist", "zedpm.tar.gz"), []byte("tarball"), 0o644))
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+	require.NoError(t, mint.CreatePullRequest(ctx))
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	require.NoError(t, publish.Check(ctx))
+	require.NoError(t, publish.MergePullRequest(ctx))
+	require.NoError(t, publish.CreateRelease(ctx))
+
+	assert.Equal(t, []string{
+		"POST /pulls",
+		"GET /pulls",
+		"GET /commits/abc123/status",
+		"GET /pulls",
+		"GET /commits/abc123/status",
+		"GET /pulls",
+		"POST /pulls/1/merge",
+		"POST /releases",
+		"POST /releases/5/assets",
+	}, fake.requests)
+
+	require.Len(t, fake.pulls, 1)
+	assert.Equal(t, "release-v1.2.3", fake.pulls[0]["head"])
+	assert.Equal(t, "master", fake.pulls[0]["base"])
+	assert.Equal(t, "Release v1.2.3", fake.pulls[0]["title"])
+	assert.True(t, fake.merged)
+
+	require.Len(t, fake.releases, 1)
+	assert.Equal(t, "v1.2.3", fake.releases[0]["tag_name"])
+	assert.Equal(t, "master", fake.releases[0]["target_commitish"])
+	assert.Equal(t, "Stuff.", fake.releases[0]["body"])
+	assert.Equal(t, false, fake.releases[0]["prerelease"])
+	assert.Equal(t, map[string]string{"zedpm.tar.gz": "tarball"}, fake.assets)
+}
+
+func TestReleaseCheckFailed(t *testing.T) {
+	fake, _, ctx := setup(t, nil)
+	fake.statuses = []string{"failure"}
+	fake.pulls = []map[string]any{{"head": "release-v1.2.3", "base": "master"}}
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	assert.EqualError(t, publish.Check(ctx),
+		`cannot merge release branch because it has not passed check "ci/test"`)
+	assert.False(t, fake.merged)
+}
+
+func TestReleaseMissingArtifact(t *testing.T) {
+	fake, _, ctx := setup(t, map[string]string{
+		goals.PropertyReleaseArtifacts: "dist/*.zip",
+	})
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	assert.EqualError(t, publish.Check(ctx),
+		`failed to find release artifacts: release.artifacts pattern "dist/*.zip" matches no files`)
+	assert.Empty(t, fake.requests)
+	assert.False(t, fake.merged)
+	assert.Empty(t, fake.releases)
+}
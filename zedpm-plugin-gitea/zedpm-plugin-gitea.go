@@ -0,0 +1,11 @@
+// Package main is the command that runs the zedpm-plugin-gitea plugin.
+package main
+
+import (
+	"github.com/zostay/zedpm/plugin/metal"
+	"github.com/zostay/zedpm/zedpm-plugin-gitea/giteaImpl"
+)
+
+func main() {
+	metal.RunPlugin(&giteaImpl.Plugin{})
+}
@@ -13,13 +13,14 @@ var _ plugin.Interface = &Plugin{}
 // Plugin implements plugin.Interface for handling github-related tasks.
 type Plugin struct{}
 
-// Implements returns the task descriptions for the /release/mint/github and
-// /release/publish/github tasks.
+// Implements returns the task descriptions for the /release/mint/github,
+// /release/publish/github, and /release/artifacts/github tasks.
 func (p *Plugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
 	rel := goals.DescribeRelease()
 	return []plugin.TaskDescription{
 		rel.Task("mint", "github", "Create a Github pull request."),
 		rel.Task("publish", "github", "Publish a release.", "mint"),
+		rel.Task("artifacts", "github", "Upload release artifacts.", "publish"),
 	}, nil
 }
 
@@ -30,14 +31,19 @@ func (p *Plugin) Goal(context.Context, string) (plugin.GoalDescription, error) {
 
 // Prepare returns the implemented tasks.
 func (p *Plugin) Prepare(
-	_ context.Context,
+	ctx context.Context,
 	task string,
 ) (plugin.Task, error) {
 	switch task {
 	case "/release/mint/github":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleaseMintTask{}, nil
 	case "/release/publish/github":
+		goals.RequirePropertyReleaseVersion(ctx)
 		return &ReleasePublishTask{}, nil
+	case "/release/artifacts/github":
+		goals.RequirePropertyReleaseVersion(ctx)
+		return &ReleaseArtifactsTask{}, nil
 	}
 	return nil, plugin.ErrUnsupportedTask
 }
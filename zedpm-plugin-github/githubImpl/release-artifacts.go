@@ -0,0 +1,229 @@
+package githubImpl
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v49/github"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGithub "github.com/zostay/zedpm/pkg/github"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleaseArtifactsTask implements the /release/artifacts/github task, which
+// attaches the files named by release.artifacts to the release created by
+// /release/publish/github.
+type ReleaseArtifactsTask struct {
+	plugin.TaskBoilerplate
+	zGithub.Github
+
+	artifacts []string
+	uploaded  map[string]*github.ReleaseAsset
+}
+
+// Setup configures the github and git clients.
+func (a *ReleaseArtifactsTask) Setup(ctx context.Context) error {
+	return a.SetupGithubClient(ctx)
+}
+
+// Check finds the artifacts to upload, failing if any pattern in
+// release.artifacts matches no files.
+func (a *ReleaseArtifactsTask) Check(ctx context.Context) error {
+	var err error
+	a.artifacts, err = goals.GetPropertyReleaseArtifacts(ctx)
+	return err
+}
+
+// UploadArtifacts uploads each artifact to the release. Each uploaded asset is
+// deleted again during cleanup if the task fails.
+func (a *ReleaseArtifactsTask) UploadArtifacts(ctx context.Context) error {
+	if len(a.artifacts) == 0 {
+		return nil
+	}
+
+	logger := plugin.Logger(ctx, "operation", "UploadArtifacts")
+	logger.StartAction("UploadArtifacts", "Uploading release artifacts to Github", "spin")
+
+	owner, project, err := a.OwnerProject(ctx)
+	if err != nil {
+		logger.MarkAction("UploadArtifacts", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger = logger.With("owner", owner, "project", project)
+	logger.TickAction("UploadArtifacts")
+
+	tag, err := git.GetPropertyGitReleaseTag(ctx)
+	if err != nil {
+		logger.MarkAction("UploadArtifacts", log.Fail)
+		return format.WrapErr(err, "failed to get release tag name")
+	}
+
+	logger = logger.With("tag", tag)
+	logger.TickAction("UploadArtifacts")
+
+	rel, _, err := a.Client().Repositories.GetReleaseByTag(ctx, owner, project, tag)
+	if err != nil {
+		logger.MarkAction("UploadArtifacts", log.Fail)
+		return format.WrapErr(err, "unable to find release for tag %q", tag)
+	}
+
+	a.uploaded = make(map[string]*github.ReleaseAsset, len(a.artifacts))
+	for _, artifact := range a.artifacts {
+		logger.TickAction("UploadArtifacts")
+
+		asset, err := a.uploadArtifact(ctx, owner, project, rel.GetID(), artifact)
+		if err != nil {
+			logger.MarkAction("UploadArtifacts", log.Fail)
+			return err
+		}
+
+		a.uploaded[artifact] = asset
+
+		id := asset.GetID()
+		plugin.ForCleanup(ctx, func() {
+			_, _ = a.Client().Repositories.DeleteReleaseAsset(context.Background(), owner, project, id)
+		})
+
+		logger.With("artifact", artifact).Info("Uploaded artifact to the release.")
+	}
+
+	logger.MarkAction("UploadArtifacts", log.Pass)
+
+	return nil
+}
+
+// uploadArtifact uploads a single file as a release asset.
+func (a *ReleaseArtifactsTask) uploadArtifact(
+	ctx context.Context,
+	owner, project string,
+	releaseID int64,
+	artifact string,
+) (*github.ReleaseAsset, error) {
+	f, err := os.Open(artifact)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to open release artifact %q", artifact)
+	}
+	defer func() { _ = f.Close() }()
+
+	asset, _, err := a.Client().Repositories.UploadReleaseAsset(ctx, owner, project, releaseID,
+		&github.UploadOptions{Name: filepath.Base(artifact)}, f)
+	if err != nil {
+		return nil, format.WrapErr(err, "failed to upload release artifact %q", artifact)
+	}
+
+	return asset, nil
+}
+
+// fileDigest returns the size and SHA-256 digest of the named file.
+func fileDigest(name string) (int64, []byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return n, h.Sum(nil), nil
+}
+
+// VerifyArtifacts checks that the size of each uploaded asset matches its file
+// and that the asset downloaded from Github has the same SHA-256 digest.
+func (a *ReleaseArtifactsTask) VerifyArtifacts(ctx context.Context) error {
+	if len(a.uploaded) == 0 {
+		return nil
+	}
+
+	logger := plugin.Logger(ctx, "operation", "VerifyArtifacts")
+	logger.StartAction("VerifyArtifacts", "Verifying release artifacts on Github", "spin")
+
+	owner, project, err := a.OwnerProject(ctx)
+	if err != nil {
+		logger.MarkAction("VerifyArtifacts", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	for _, artifact := range a.artifacts {
+		logger.TickAction("VerifyArtifacts")
+
+		err := a.verifyArtifact(ctx, owner, project, artifact, a.uploaded[artifact])
+		if err != nil {
+			logger.MarkAction("VerifyArtifacts", log.Fail)
+			return err
+		}
+	}
+
+	logger.MarkAction("VerifyArtifacts", log.Pass)
+
+	return nil
+}
+
+// verifyArtifact checks a single uploaded asset against its file.
+func (a *ReleaseArtifactsTask) verifyArtifact(
+	ctx context.Context,
+	owner, project string,
+	artifact string,
+	asset *github.ReleaseAsset,
+) error {
+	size, digest, err := fileDigest(artifact)
+	if err != nil {
+		return format.WrapErr(err, "unable to read release artifact %q", artifact)
+	}
+
+	if int64(asset.GetSize()) != size {
+		return fmt.Errorf("release asset %q has %d bytes, but the artifact has %d bytes",
+			asset.GetName(), asset.GetSize(), size)
+	}
+
+	rc, _, err := a.Client().Repositories.DownloadReleaseAsset(ctx, owner, project, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return format.WrapErr(err, "unable to download release asset %q", asset.GetName())
+	}
+	defer func() { _ = rc.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return format.WrapErr(err, "unable to download release asset %q", asset.GetName())
+	}
+
+	if !bytes.Equal(h.Sum(nil), digest) {
+		return fmt.Errorf("release asset %q does not match the SHA-256 digest of %q", asset.GetName(), artifact)
+	}
+
+	return nil
+}
+
+// Run configures UploadArtifacts to run.
+func (a *ReleaseArtifactsTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(a.UploadArtifacts),
+		},
+	}, nil
+}
+
+// End configures VerifyArtifacts to run.
+func (a *ReleaseArtifactsTask) End(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(a.VerifyArtifacts),
+		},
+	}, nil
+}
@@ -73,13 +73,15 @@ func (s *ReleaseMintTask) CreateGithubPullRequest(ctx context.Context) error {
 		"pullRequestName", prName,
 	)
 
+	var pr *github.PullRequest
 	for retries := 3; retries > 0; retries-- {
 		logger.MarkAction("CreateGithubPullRequest", log.Working)
-		_, _, err = s.Client().PullRequests.Create(ctx, owner, project, &github.NewPullRequest{
+		pr, _, err = s.Client().PullRequests.Create(ctx, owner, project, &github.NewPullRequest{
 			Title: github.String(prName),
 			Head:  github.String(branch),
 			Base:  github.String(targetBranch),
 			Body:  github.String(body),
+			Draft: github.Bool(zGithub.GetPropertyGithubPullRequestDraft(ctx)),
 		})
 
 		logger.TickAction("CreateGithubPullRequest")
@@ -99,11 +101,54 @@ func (s *ReleaseMintTask) CreateGithubPullRequest(ctx context.Context) error {
 		return format.WrapErr(err, "unable to create pull request")
 	}
 
+	logger = logger.With("pullRequestID", pr.GetNumber())
+	logger.TickAction("CreateGithubPullRequest")
+
+	err = s.addPullRequestMetadata(ctx, owner, project, pr.GetNumber())
+	if err != nil {
+		logger.MarkAction("CreateGithubPullRequest", log.Fail)
+		return err
+	}
+
 	logger.MarkAction("CreateGithubPullRequest", log.Pass)
 
 	return nil
 }
 
+// addPullRequestMetadata adds the labels, assignees, and reviewers configured
+// in the github.pullRequest properties to the pull request.
+func (s *ReleaseMintTask) addPullRequestMetadata(
+	ctx context.Context,
+	owner, project string,
+	number int,
+) error {
+	if labels := zGithub.GetPropertyGithubPullRequestLabels(ctx); len(labels) > 0 {
+		_, _, err := s.Client().Issues.AddLabelsToIssue(ctx, owner, project, number, labels)
+		if err != nil {
+			return format.WrapErr(err, "unable to add labels to pull request %d", number)
+		}
+	}
+
+	if assignees := zGithub.GetPropertyGithubPullRequestAssignees(ctx); len(assignees) > 0 {
+		_, _, err := s.Client().Issues.AddAssignees(ctx, owner, project, number, assignees)
+		if err != nil {
+			return format.WrapErr(err, "unable to add assignees to pull request %d", number)
+		}
+	}
+
+	if users, teams := zGithub.GetPropertyGithubPullRequestReviewers(ctx); len(users) > 0 || len(teams) > 0 {
+		_, _, err := s.Client().PullRequests.RequestReviewers(ctx, owner, project, number, github.ReviewersRequest{
+			Reviewers:     users,
+			TeamReviewers: teams,
+		})
+		if err != nil {
+			return format.WrapErr(err, "unable to request reviewers for pull request %d", number)
+		}
+	}
+
+	return nil
+}
+
 // End configures CreateGithubPullRequest to run.
 func (s *ReleaseMintTask) End(context.Context) (plugin.Operations, error) {
 	return plugin.Operations{
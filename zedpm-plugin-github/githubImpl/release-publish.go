@@ -3,6 +3,7 @@ package githubImpl
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -172,23 +173,61 @@ func (f *ReleasePublishTask) MergePullRequest(ctx context.Context) error {
 	logger = logger.With("branch", branch)
 	logger.TickAction("MergePullRequest")
 
-	prId := 0
-	for _, pr := range prs {
-		if pr.Head.GetRef() == branch {
-			prId = pr.GetNumber()
+	var pr *github.PullRequest
+	for _, p := range prs {
+		if p.Head.GetRef() == branch {
+			pr = p
 			break
 		}
 	}
 
-	if prId == 0 {
+	if pr == nil {
 		logger.MarkAction("MergePullRequest", log.Fail)
 		return fmt.Errorf("cannot find pull request for branch %s", branch)
 	}
 
+	prId := pr.GetNumber()
 	logger = logger.With("pullRequestID", prId)
 	logger.TickAction("MergePullRequest")
 
-	m, _, err := f.Client().PullRequests.Merge(ctx, owner, project, prId, "Merging release branch.", &github.PullRequestOptions{})
+	// Github refuses to merge a draft, which the mint phase opens when
+	// github.pullRequest.draft is set
+	if pr.GetDraft() {
+		err := f.MarkPullRequestReady(ctx, pr.GetNodeID())
+		if err != nil {
+			logger.MarkAction("MergePullRequest", log.Fail)
+			return format.WrapErr(err, "cannot merge draft pull request %d", prId)
+		}
+
+		logger.Info("Marked the draft pull request ready for review.")
+		logger.TickAction("MergePullRequest")
+	}
+
+	method, err := zGithub.GetPropertyGithubMergeMethod(ctx)
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return err
+	}
+
+	title, err := zGithub.GetPropertyGithubMergeTitle(ctx)
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return format.WrapErr(err, "failed to get merge commit title")
+	}
+
+	message, err := zGithub.GetPropertyGithubMergeMessage(ctx)
+	if err != nil {
+		logger.MarkAction("MergePullRequest", log.Fail)
+		return format.WrapErr(err, "failed to get merge commit message")
+	}
+
+	logger = logger.With("mergeMethod", method)
+	logger.TickAction("MergePullRequest")
+
+	m, _, err := f.Client().PullRequests.Merge(ctx, owner, project, prId, message, &github.PullRequestOptions{
+		CommitTitle: title,
+		MergeMethod: method,
+	})
 	if err != nil {
 		logger.MarkAction("MergePullRequest", log.Fail)
 		return format.WrapErr(err, "unable to merge pull request %d", prId)
@@ -199,9 +238,27 @@ func (f *ReleasePublishTask) MergePullRequest(ctx context.Context) error {
 		return fmt.Errorf("failed to merge pull request %d", prId)
 	}
 
-	logger.MarkAction("MergePullRequest", log.Pass)
 	logger.Info("Merged the pull request into the target branch.")
 
+	if zGithub.GetPropertyGithubMergeDeleteBranch(ctx) {
+		logger.TickAction("MergePullRequest")
+
+		res, err := f.Client().Git.DeleteRef(ctx, owner, project, "heads/"+branch)
+		switch {
+		case err == nil:
+			logger.Info("Deleted the release branch.")
+		case res != nil && res.StatusCode == http.StatusUnprocessableEntity:
+			// Github refuses with 422 when the branch is already gone, e.g.,
+			// when the repository deletes head branches automatically
+			logger.Info("The release branch was already deleted.")
+		default:
+			logger.MarkAction("MergePullRequest", log.Fail)
+			return format.WrapErr(err, "unable to delete release branch %s", branch)
+		}
+	}
+
+	logger.MarkAction("MergePullRequest", log.Pass)
+
 	return nil
 }
 
@@ -237,6 +294,14 @@ func (f *ReleasePublishTask) CreateRelease(ctx context.Context) error {
 	logger = logger.With("releaseName", releaseName)
 	logger.TickAction("CreateRelease")
 
+	prerelease := goals.GetPropertyReleasePreRelease(ctx)
+	makeLatest := "true"
+	if prerelease {
+		makeLatest = "false"
+	}
+
+	logger = logger.With("prerelease", prerelease)
+
 	changesInfo := goals.GetPropertyReleaseDescription(ctx)
 	_, _, err = f.Client().Repositories.CreateRelease(ctx, owner, project,
 		&github.RepositoryRelease{
@@ -244,9 +309,9 @@ func (f *ReleasePublishTask) CreateRelease(ctx context.Context) error {
 			Name:                 github.String(releaseName),
 			Body:                 github.String(changesInfo),
 			Draft:                github.Bool(false),
-			Prerelease:           github.Bool(false),
+			Prerelease:           github.Bool(prerelease),
 			GenerateReleaseNotes: github.Bool(false),
-			MakeLatest:           github.String("true"),
+			MakeLatest:           github.String(makeLatest),
 		},
 	)
 
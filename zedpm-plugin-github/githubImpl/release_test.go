@@ -0,0 +1,404 @@
+package githubImpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/forgetest"
+	zGithub "github.com/zostay/zedpm/pkg/github"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// fakeGithub is an httptest stand-in for the parts of the Github API used by
+// the release tasks.
+type fakeGithub struct {
+	lock     sync.Mutex
+	requests []string
+	pulls    []map[string]any
+	releases []map[string]any
+	merged   bool
+	merge    map[string]any
+	metadata map[string]any
+	assets   map[string]string
+	deleted  []string
+	corrupt  bool
+	gone     bool
+}
+
+// assetName returns the name of the asset with the given ID, which is the
+// order in which it was uploaded.
+func (f *fakeGithub) assetName(id string) string {
+	names := make([]string, 0, len(f.assets))
+	for name := range f.assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	n, _ := strconv.Atoi(id)
+	return names[n-1]
+}
+
+// pull returns the API representation of the numbered pull request.
+func (f *fakeGithub) pull(number int) map[string]any {
+	pr := f.pulls[number-1]
+	return map[string]any{
+		"number":  number,
+		"node_id": fmt.Sprintf("PR_%d", number),
+		"title":   pr["title"],
+		"draft":   pr["draft"],
+		"head":    map[string]any{"ref": pr["head"]},
+		"base":    map[string]any{"ref": pr["base"]},
+	}
+}
+
+func (f *fakeGithub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	const repo = "/api/v3/repos/zostay/zedpm"
+	const uploads = "/api/uploads/repos/zostay/zedpm"
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, repo)
+	if strings.HasPrefix(r.URL.Path, uploads) {
+		route = r.Method + " uploads" + strings.TrimPrefix(r.URL.Path, uploads)
+	}
+	f.requests = append(f.requests, route)
+
+	setMetadata := func(key string) {
+		var body any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if f.metadata == nil {
+			f.metadata = map[string]any{}
+		}
+		f.metadata[key] = body
+	}
+
+	switch route {
+	case "POST /pulls":
+		f.pulls = append(f.pulls, forgetest.Decode(r))
+		forgetest.Reply(w, http.StatusCreated, f.pull(len(f.pulls)))
+	case "GET /pulls":
+		pulls := make([]any, len(f.pulls))
+		for i := range f.pulls {
+			pulls[i] = f.pull(i + 1)
+		}
+		forgetest.Reply(w, http.StatusOK, pulls)
+	case "GET /branches/master/protection":
+		forgetest.Reply(w, http.StatusOK, map[string]any{
+			"required_status_checks": map[string]any{
+				"checks": []any{map[string]any{"context": "test"}},
+			},
+		})
+	case "GET /commits/release-v1.2.3/check-runs":
+		forgetest.Reply(w, http.StatusOK, map[string]any{
+			"total_count": 1,
+			"check_runs": []any{map[string]any{
+				"name":       "test",
+				"status":     "completed",
+				"conclusion": "success",
+			}},
+		})
+	case "POST /issues/1/labels":
+		setMetadata("labels")
+		forgetest.Reply(w, http.StatusOK, []any{})
+	case "POST /issues/1/assignees":
+		setMetadata("assignees")
+		forgetest.Reply(w, http.StatusCreated, map[string]any{"number": 1})
+	case "POST /pulls/1/requested_reviewers":
+		setMetadata("reviewers")
+		forgetest.Reply(w, http.StatusCreated, f.pull(1))
+	case "PUT /pulls/1/merge":
+		f.merged = true
+		f.merge = forgetest.Decode(r)
+		forgetest.Reply(w, http.StatusOK, map[string]any{"merged": true})
+	case "DELETE /git/refs/heads/release-v1.2.3":
+		if f.gone {
+			forgetest.Reply(w, http.StatusUnprocessableEntity, map[string]any{"message": "Reference does not exist"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "POST /api/graphql":
+		vars, _ := forgetest.Decode(r)["variables"].(map[string]any)
+		for i := range f.pulls {
+			if vars["id"] == fmt.Sprintf("PR_%d", i+1) {
+				f.pulls[i]["draft"] = false
+				forgetest.Reply(w, http.StatusOK, map[string]any{"data": map[string]any{}})
+				return
+			}
+		}
+		forgetest.Reply(w, http.StatusOK, map[string]any{
+			"errors": []any{map[string]any{"message": "Could not resolve to a node"}},
+		})
+	case "POST /releases":
+		rel := forgetest.Decode(r)
+		rel["id"] = len(f.releases) + 1
+		f.releases = append(f.releases, rel)
+		forgetest.Reply(w, http.StatusCreated, rel)
+	case "GET /releases/tags/v1.2.3":
+		forgetest.Reply(w, http.StatusOK, map[string]any{"id": 1, "tag_name": "v1.2.3"})
+	case "POST uploads/releases/1/assets":
+		data, _ := io.ReadAll(r.Body)
+		name := r.URL.Query().Get("name")
+		f.assets[name] = string(data)
+		forgetest.Reply(w, http.StatusCreated, map[string]any{"id": len(f.assets), "name": name, "size": len(data)})
+	case "GET /releases/assets/1", "GET /releases/assets/2":
+		name := f.assetName(path.Base(r.URL.Path))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		data := f.assets[name]
+		if f.corrupt {
+			data = strings.ToUpper(data)
+		}
+		_, _ = w.Write([]byte(data))
+	case "DELETE /releases/assets/1", "DELETE /releases/assets/2":
+		name := f.assetName(path.Base(r.URL.Path))
+		f.deleted = append(f.deleted, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		forgetest.Reply(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+	}
+}
+
+// setup starts the Github stand-in and returns it with a plugin context
+// configured to use it.
+func setup(t *testing.T, props map[string]string) (*fakeGithub, context.Context) {
+	t.Helper()
+	return setupLogged(t, io.Discard, props)
+}
+
+// setupLogged is like setup, but writes the log to w.
+func setupLogged(t *testing.T, w io.Writer, props map[string]string) (*fakeGithub, context.Context) {
+	t.Helper()
+
+	fake := &fakeGithub{assets: map[string]string{}}
+	baseURL := forgetest.NewServer(t, fake)
+
+	forgetest.InitRepo(t, "git@127.0.0.1:zostay/zedpm.git", "master", "initial")
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	return fake, forgetest.LoggedContext(w, map[string]string{
+		zGithub.PropertyGithubBaseURL: baseURL,
+		goals.PropertyReleaseVersion:  "1.2.3",
+	}, props)
+}
+
+func TestReleaseEnterprise(t *testing.T) {
+	fake, ctx := setup(t, map[string]string{
+		goals.PropertyReleaseDescription: "Stuff.",
+	})
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+	require.NoError(t, mint.CreateGithubPullRequest(ctx))
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	require.NoError(t, publish.Check(ctx))
+	require.NoError(t, publish.MergePullRequest(ctx))
+	require.NoError(t, publish.CreateRelease(ctx))
+
+	assert.Equal(t, []string{
+		"POST /pulls",
+		"GET /branches/master/protection",
+		"GET /commits/release-v1.2.3/check-runs",
+		"GET /pulls",
+		"PUT /pulls/1/merge",
+		"POST /releases",
+	}, fake.requests)
+
+	require.Len(t, fake.pulls, 1)
+	assert.Equal(t, "Release v1.2.3", fake.pulls[0]["title"])
+	assert.Equal(t, "master", fake.pulls[0]["base"])
+	assert.True(t, fake.merged)
+
+	require.Len(t, fake.releases, 1)
+	assert.Equal(t, "v1.2.3", fake.releases[0]["tag_name"])
+	assert.Equal(t, "Stuff.", fake.releases[0]["body"])
+	assert.Equal(t, false, fake.releases[0]["prerelease"])
+}
+
+func TestReleaseEnterpriseWrongHost(t *testing.T) {
+	forgetest.InitRepo(t, "git@github.com:zostay/zedpm.git", "master", "initial")
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	ctx := forgetest.Context(map[string]string{
+		zGithub.PropertyGithubBaseURL: "https://ghe.example.com/api/v3/",
+	})
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+
+	_, _, err := mint.OwnerProject(ctx)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"unable to determine Github project and owner from git remote configuration: remote URL %q is not on the Github host %q",
+		"git@github.com:zostay/zedpm.git", "ghe.example.com"))
+}
+
+func TestReleaseArtifacts(t *testing.T) {
+	for _, corrupt := range []bool{false, true} {
+		fake, ctx := setup(t, map[string]string{
+			goals.PropertyReleaseArtifacts: "dist/*.tar.gz",
+		})
+		fake.corrupt = corrupt
+
+		require.NoError(t, os.MkdirAll("dist", 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join("dist", "a.tar.gz"), []byte("tarball"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join("dist", "checksums.txt"), []byte("sums"), 0o644))
+		require.NoError(t, goals.AddReleaseArtifacts(ctx, filepath.Join("dist", "checksums.txt")))
+
+		artifacts := &ReleaseArtifactsTask{}
+		require.NoError(t, artifacts.Setup(ctx))
+		require.NoError(t, artifacts.Check(ctx))
+		require.NoError(t, artifacts.UploadArtifacts(ctx))
+		assert.Equal(t, map[string]string{"a.tar.gz": "tarball", "checksums.txt": "sums"}, fake.assets)
+
+		err := artifacts.VerifyArtifacts(ctx)
+		if !corrupt {
+			assert.NoError(t, err)
+			continue
+		}
+
+		assert.EqualError(t, err, `release asset "a.tar.gz" does not match the SHA-256 digest of "dist/a.tar.gz"`)
+
+		// a failed task runs its cleanup tasks, which delete the assets
+		for _, cleanup := range plugin.ListCleanupTasks(ctx) {
+			cleanup()
+		}
+		assert.Equal(t, []string{"checksums.txt", "a.tar.gz"}, fake.deleted)
+	}
+}
+
+func TestReleaseArtifactsRegistered(t *testing.T) {
+	fake, ctx := setup(t, nil)
+
+	// another plugin builds its artifact outside the module and registers it
+	built := filepath.Join(t.TempDir(), "zedpm.zip")
+	require.NoError(t, os.WriteFile(built, []byte("zip"), 0o644))
+	require.NoError(t, goals.AddReleaseArtifacts(ctx, built))
+
+	artifacts := &ReleaseArtifactsTask{}
+	require.NoError(t, artifacts.Setup(ctx))
+	require.NoError(t, artifacts.Check(ctx))
+	require.NoError(t, artifacts.UploadArtifacts(ctx))
+	require.NoError(t, artifacts.VerifyArtifacts(ctx))
+	assert.Equal(t, map[string]string{"zedpm.zip": "zip"}, fake.assets)
+
+	// a second artifact with the same name would replace the first
+	require.NoError(t, os.MkdirAll("dist", 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join("dist", "zedpm.zip"), []byte("other"), 0o644))
+	plugin.Set(ctx, goals.PropertyReleaseArtifacts, "dist/*.zip,"+built)
+
+	artifacts = &ReleaseArtifactsTask{}
+	require.NoError(t, artifacts.Setup(ctx))
+	assert.EqualError(t, artifacts.Check(ctx), fmt.Sprintf(
+		"release artifacts %q and %q would both be attached as %q",
+		filepath.Join("dist", "zedpm.zip"), built, "zedpm.zip"))
+}
+
+func TestReleaseMergeOptions(t *testing.T) {
+	fake, ctx := setup(t, map[string]string{
+		zGithub.PropertyGithubMergeMethod:          "squash",
+		zGithub.PropertyGithubMergeTitle:           "Release v{{ .release.version }}",
+		zGithub.PropertyGithubMergeMessage:         "{{ .release.description }}",
+		zGithub.PropertyGithubMergeDeleteBranch:    "true",
+		zGithub.PropertyGithubPullRequestLabels:    "release, automated",
+		zGithub.PropertyGithubPullRequestAssignees: "zostay",
+		zGithub.PropertyGithubPullRequestReviewers: "octocat,zostay/maintainers",
+		goals.PropertyReleaseDescription:           "Stuff.",
+	})
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+	require.NoError(t, mint.CreateGithubPullRequest(ctx))
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	require.NoError(t, publish.MergePullRequest(ctx))
+
+	assert.Equal(t, []string{
+		"POST /pulls",
+		"POST /issues/1/labels",
+		"POST /issues/1/assignees",
+		"POST /pulls/1/requested_reviewers",
+		"GET /pulls",
+		"PUT /pulls/1/merge",
+		"DELETE /git/refs/heads/release-v1.2.3",
+	}, fake.requests)
+
+	require.Len(t, fake.pulls, 1)
+	assert.Equal(t, false, fake.pulls[0]["draft"])
+	assert.Equal(t, map[string]any{
+		"labels":    []any{"release", "automated"},
+		"assignees": map[string]any{"assignees": []any{"zostay"}},
+		"reviewers": map[string]any{
+			"reviewers":      []any{"octocat"},
+			"team_reviewers": []any{"maintainers"},
+		},
+	}, fake.metadata)
+	assert.Equal(t, map[string]any{
+		"commit_title":   "Release v1.2.3",
+		"commit_message": "Stuff.",
+		"merge_method":   "squash",
+	}, fake.merge)
+}
+
+func TestReleaseMergeDraft(t *testing.T) {
+	var logs bytes.Buffer
+	fake, ctx := setupLogged(t, &logs, map[string]string{
+		zGithub.PropertyGithubPullRequestDraft:  "true",
+		zGithub.PropertyGithubMergeDeleteBranch: "true",
+	})
+	fake.gone = true
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+	require.NoError(t, mint.CreateGithubPullRequest(ctx))
+	assert.Equal(t, true, fake.pulls[0]["draft"])
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	require.NoError(t, publish.MergePullRequest(ctx))
+
+	assert.Equal(t, []string{
+		"POST /pulls",
+		"GET /pulls",
+		"POST /api/graphql",
+		"PUT /pulls/1/merge",
+		"DELETE /git/refs/heads/release-v1.2.3",
+	}, fake.requests)
+	assert.Equal(t, false, fake.pulls[0]["draft"])
+	assert.True(t, fake.merged)
+
+	assert.Contains(t, logs.String(), "Marked the draft pull request ready for review.")
+	assert.Contains(t, logs.String(), "The release branch was already deleted.")
+	assert.NotContains(t, logs.String(), "Deleted the release branch.")
+}
+
+func TestReleaseMergeErrors(t *testing.T) {
+	fake, ctx := setup(t, map[string]string{
+		zGithub.PropertyGithubMergeMethod: "octopus",
+	})
+	fake.pulls = []map[string]any{{"head": "release-v1.2.3", "base": "master", "draft": false}}
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	assert.EqualError(t, publish.MergePullRequest(ctx),
+		`github.merge.method must be one of "merge", "squash", or "rebase", not "octopus"`)
+	assert.False(t, fake.merged)
+
+	assert.EqualError(t, publish.MarkPullRequestReady(ctx, "PR_9"),
+		"unable to mark pull request ready for review: Could not resolve to a node")
+}
@@ -0,0 +1,2 @@
+// Package gitlabImpl implements the zedpm-plugin-gitlab plugin.
+package gitlabImpl
@@ -0,0 +1,59 @@
+package gitlabImpl
+
+import (
+	"context"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// Verifies that Plugin implements plugin.Interface.
+var _ plugin.Interface = &Plugin{}
+
+// Plugin implements plugin.Interface for handling GitLab-related tasks.
+type Plugin struct{}
+
+// Implements returns the task descriptions for the /release/mint/gitlab,
+// /release/publish/gitlab, and /request/create/gitlab tasks.
+func (p *Plugin) Implements(context.Context) ([]plugin.TaskDescription, error) {
+	rel := goals.DescribeRelease()
+	req := goals.DescribeRequest()
+	return []plugin.TaskDescription{
+		rel.Task("mint", "gitlab", "Create a GitLab merge request."),
+		rel.Task("publish", "gitlab", "Merge and publish a GitLab release.", "mint"),
+		req.Task("create", "gitlab", "Create a GitLab merge request for the current branch."),
+	}, nil
+}
+
+// Goal returns plugin.ErrUnsupportedGoal.
+func (p *Plugin) Goal(context.Context, string) (plugin.GoalDescription, error) {
+	return nil, plugin.ErrUnsupportedGoal
+}
+
+// Prepare returns the implemented tasks.
+func (p *Plugin) Prepare(
+	ctx context.Context,
+	task string,
+) (plugin.Task, error) {
+	switch task {
+	case "/release/mint/gitlab":
+		goals.RequirePropertyReleaseVersion(ctx)
+		return &ReleaseMintTask{}, nil
+	case "/release/publish/gitlab":
+		goals.RequirePropertyReleaseVersion(ctx)
+		return &ReleasePublishTask{}, nil
+	case "/request/create/gitlab":
+		return &RequestCreateTask{}, nil
+	}
+	return nil, plugin.ErrUnsupportedTask
+}
+
+// Cancel is a no-op.
+func (p *Plugin) Cancel(ctx context.Context, task plugin.Task) error {
+	return nil
+}
+
+// Complete is a no-op.
+func (p *Plugin) Complete(ctx context.Context, task plugin.Task) error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package gitlabImpl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGitlab "github.com/zostay/zedpm/pkg/gitlab"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleaseMintTask implements the /release/mint/gitlab task.
+type ReleaseMintTask struct {
+	plugin.TaskBoilerplate
+	zGitlab.Gitlab
+}
+
+// Setup configures the GitLab and git clients.
+func (s *ReleaseMintTask) Setup(ctx context.Context) error {
+	return s.SetupGitlabClient(ctx)
+}
+
+// CreateMergeRequest creates the merge request on GitLab for monitoring the
+// pipeline for release testing. This will also be used to merge the release
+// branch when the pipeline passes.
+func (s *ReleaseMintTask) CreateMergeRequest(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CreateMergeRequest")
+	logger.StartAction("CreateMergeRequest", "Creating GitLab merge request", "spin")
+
+	projectID, err := s.ProjectID(ctx)
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger.TickAction("CreateMergeRequest")
+
+	branch, err := git.GetPropertyGitReleaseBranch(ctx)
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed to get release branch name")
+	}
+
+	logger.TickAction("CreateMergeRequest")
+
+	mrName, err := zGitlab.GetPropertyGitlabReleaseName(ctx)
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed to get release name")
+	}
+
+	logger.TickAction("CreateMergeRequest")
+
+	body := fmt.Sprintf("Merge request to complete %q of project.", mrName)
+	if version, err := goals.GetPropertyReleaseVersion(ctx); err == nil {
+		body = fmt.Sprintf("Merge request to complete release for v%s of project.", version)
+	}
+
+	targetBranch := git.GetPropertyGitTargetBranch(ctx)
+	logger = logger.With(
+		"project", projectID,
+		"branch", branch,
+		"targetBranch", targetBranch,
+		"mergeRequestName", mrName,
+	)
+
+	var mr *zGitlab.MergeRequest
+	for retries := 3; retries > 0; retries-- {
+		logger.MarkAction("CreateMergeRequest", log.Working)
+		mr, err = s.Client().CreateMergeRequest(ctx, projectID, &zGitlab.NewMergeRequest{
+			SourceBranch:       branch,
+			TargetBranch:       targetBranch,
+			Title:              mrName,
+			Description:        body,
+			RemoveSourceBranch: true,
+		})
+
+		logger.TickAction("CreateMergeRequest")
+
+		if err == nil {
+			break
+		}
+
+		logger.MarkAction("CreateMergeRequest", log.Retry)
+
+		<-time.After(5 * time.Second)
+	}
+
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "unable to create merge request")
+	}
+
+	logger.MarkAction("CreateMergeRequest", log.Pass)
+	logger.With("mergeRequestIID", mr.IID, "url", mr.WebURL).Info("Created the merge request.")
+
+	return nil
+}
+
+// End configures CreateMergeRequest to run.
+func (s *ReleaseMintTask) End(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  80,
+			Action: plugin.OperationFunc(s.CreateMergeRequest),
+		},
+	}, nil
+}
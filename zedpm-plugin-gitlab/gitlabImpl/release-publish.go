@@ -0,0 +1,220 @@
+package gitlabImpl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGitlab "github.com/zostay/zedpm/pkg/gitlab"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// ReleasePublishTask implements the /release/publish/gitlab task.
+type ReleasePublishTask struct {
+	plugin.TaskBoilerplate
+	zGitlab.Gitlab
+}
+
+// Setup configures the GitLab and git clients.
+func (f *ReleasePublishTask) Setup(ctx context.Context) error {
+	return f.SetupGitlabClient(ctx)
+}
+
+// errPipelineRunning is returned by CheckPipeline while the pipeline has not
+// finished.
+var errPipelineRunning = fmt.Errorf("pipeline is still running")
+
+// findMergeRequest returns the open merge request for the release branch.
+func (f *ReleasePublishTask) findMergeRequest(
+	ctx context.Context,
+	projectID string,
+) (*zGitlab.MergeRequest, error) {
+	branch, err := git.GetPropertyGitReleaseBranch(ctx)
+	if err != nil {
+		return nil, format.WrapErr(err, "failed to get release branch name")
+	}
+
+	mr, err := f.Client().FindMergeRequest(ctx, projectID, branch)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to find merge request for branch %s", branch)
+	}
+
+	if mr == nil {
+		return nil, fmt.Errorf("cannot find merge request for branch %s", branch)
+	}
+
+	return mr, nil
+}
+
+// CheckPipeline checks the status of the pipeline of the merge request for the
+// release branch. It returns errPipelineRunning if the pipeline has not
+// finished yet. A merge request without a pipeline is ready to merge.
+func (f *ReleasePublishTask) CheckPipeline(ctx context.Context) error {
+	projectID, err := f.ProjectID(ctx)
+	if err != nil {
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	mr, err := f.findMergeRequest(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	logger := plugin.Logger(ctx, "project", projectID, "mergeRequestIID", mr.IID)
+	pipeline := mr.HeadPipeline
+	if pipeline == nil {
+		logger.Info("Merge request is ready to merge: no pipeline")
+		return nil
+	}
+
+	switch pipeline.Status {
+	case zGitlab.PipelineSuccess:
+		logger.With("pipelineID", pipeline.ID).Info("Merge request is ready to merge: pipeline passed")
+		return nil
+	case zGitlab.PipelineFailed, zGitlab.PipelineCanceled, zGitlab.PipelineSkipped:
+		return fmt.Errorf("cannot merge release branch because pipeline %d has status %q: %s", pipeline.ID, pipeline.Status, pipeline.WebURL)
+	default:
+		return errPipelineRunning
+	}
+}
+
+// Check waits for the pipeline of the merge request to finish, checking every
+// gitlab.pipeline.interval until gitlab.pipeline.timeout has elapsed. It fails
+// if the pipeline does not succeed.
+func (f *ReleasePublishTask) Check(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CheckPipeline")
+	logger.StartAction("CheckPipeline", "Waiting for the GitLab pipeline to pass", "spin")
+
+	ctx, cancel := context.WithTimeout(ctx, zGitlab.GetPropertyGitlabPipelineTimeout(ctx))
+	defer cancel()
+
+	interval := zGitlab.GetPropertyGitlabPipelineInterval(ctx)
+	for {
+		err := f.CheckPipeline(ctx)
+		if err == nil {
+			logger.MarkAction("CheckPipeline", log.Pass)
+			return nil
+		}
+
+		if err != errPipelineRunning {
+			logger.MarkAction("CheckPipeline", log.Fail)
+			return err
+		}
+
+		logger.TickAction("CheckPipeline")
+
+		select {
+		case <-ctx.Done():
+			logger.MarkAction("CheckPipeline", log.Fail)
+			return format.WrapErr(ctx.Err(), "gave up waiting for the pipeline to pass")
+		case <-time.After(interval):
+		}
+	}
+}
+
+// MergeMergeRequest merges the merge request into the target branch.
+func (f *ReleasePublishTask) MergeMergeRequest(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "MergeMergeRequest")
+	logger.StartAction("MergeMergeRequest", "Merging merge request", "spin")
+
+	projectID, err := f.ProjectID(ctx)
+	if err != nil {
+		logger.MarkAction("MergeMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger = logger.With("project", projectID)
+	logger.TickAction("MergeMergeRequest")
+
+	mr, err := f.findMergeRequest(ctx, projectID)
+	if err != nil {
+		logger.MarkAction("MergeMergeRequest", log.Fail)
+		return err
+	}
+
+	logger = logger.With("mergeRequestIID", mr.IID)
+	logger.TickAction("MergeMergeRequest")
+
+	merged, err := f.Client().AcceptMergeRequest(ctx, projectID, mr.IID, "Merging release branch.")
+	if err != nil {
+		logger.MarkAction("MergeMergeRequest", log.Fail)
+		return format.WrapErr(err, "unable to merge merge request !%d", mr.IID)
+	}
+
+	if merged.State != zGitlab.MergeRequestMerged {
+		logger.MarkAction("MergeMergeRequest", log.Fail)
+		return fmt.Errorf("failed to merge merge request !%d", mr.IID)
+	}
+
+	logger.MarkAction("MergeMergeRequest", log.Pass)
+	logger.Info("Merged the merge request into the target branch.")
+
+	return nil
+}
+
+// CreateRelease creates a release on GitLab for the release tag, described by
+// the changelog.
+func (f *ReleasePublishTask) CreateRelease(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CreateRelease")
+	logger.StartAction("CreateRelease", "Creating a GitLab release", "spin")
+
+	projectID, err := f.ProjectID(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	logger = logger.With("project", projectID)
+	logger.TickAction("CreateRelease")
+
+	tag, err := git.GetPropertyGitReleaseTag(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to get release tag name")
+	}
+
+	logger = logger.With("tag", tag)
+	logger.TickAction("CreateRelease")
+
+	releaseName, err := zGitlab.GetPropertyGitlabReleaseName(ctx)
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to get release name")
+	}
+
+	logger = logger.With("releaseName", releaseName)
+	logger.TickAction("CreateRelease")
+
+	_, err = f.Client().CreateRelease(ctx, projectID, &zGitlab.Release{
+		TagName:     tag,
+		Name:        releaseName,
+		Description: goals.GetPropertyReleaseDescription(ctx),
+		Ref:         git.GetPropertyGitTargetBranch(ctx),
+	})
+	if err != nil {
+		logger.MarkAction("CreateRelease", log.Fail)
+		return format.WrapErr(err, "failed to create release %q", releaseName)
+	}
+
+	logger.MarkAction("CreateRelease", log.Pass)
+
+	return nil
+}
+
+// End configures MergeMergeRequest and CreateRelease to run.
+func (f *ReleasePublishTask) End(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  60,
+			Action: plugin.OperationFunc(f.MergeMergeRequest),
+		},
+		{
+			Order:  80,
+			Action: plugin.OperationFunc(f.CreateRelease),
+		},
+	}, nil
+}
@@ -0,0 +1,180 @@
+package gitlabImpl
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zostay/zedpm/pkg/forgetest"
+	zGitlab "github.com/zostay/zedpm/pkg/gitlab"
+	"github.com/zostay/zedpm/pkg/goals"
+)
+
+// fakeGitlab is an httptest stand-in for the parts of the GitLab API used by
+// the release and request tasks.
+type fakeGitlab struct {
+	lock      sync.Mutex
+	requests  []string
+	mrs       []map[string]any
+	releases  []map[string]any
+	pipelines []string
+	merged    bool
+}
+
+func (f *fakeGitlab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	const project = "/api/v4/projects/group%2Fzedpm"
+	route := r.Method + " " + strings.TrimPrefix(r.URL.EscapedPath(), project)
+	f.requests = append(f.requests, route)
+
+	if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+		forgetest.Reply(w, http.StatusUnauthorized, map[string]any{"message": "401 Unauthorized"})
+		return
+	}
+
+	switch route {
+	case "POST /merge_requests":
+		mr := forgetest.Decode(r)
+		mr["iid"] = len(f.mrs) + 1
+		mr["state"] = "opened"
+		f.mrs = append(f.mrs, mr)
+		forgetest.Reply(w, http.StatusCreated, mr)
+	case "GET /merge_requests":
+		found := []any{}
+		for _, mr := range f.mrs {
+			if mr["source_branch"] == r.URL.Query().Get("source_branch") && mr["state"] == "opened" {
+				found = append(found, mr)
+			}
+		}
+		forgetest.Reply(w, http.StatusOK, found)
+	case "GET /merge_requests/1":
+		mr := map[string]any{}
+		for k, v := range f.mrs[0] {
+			mr[k] = v
+		}
+		if len(f.pipelines) > 0 {
+			mr["head_pipeline"] = map[string]any{"id": 7, "status": f.pipelines[0]}
+			if len(f.pipelines) > 1 {
+				f.pipelines = f.pipelines[1:]
+			}
+		}
+		forgetest.Reply(w, http.StatusOK, mr)
+	case "PUT /merge_requests/1/merge":
+		f.merged = true
+		f.mrs[0]["state"] = "merged"
+		forgetest.Reply(w, http.StatusOK, f.mrs[0])
+	case "POST /releases":
+		rel := forgetest.Decode(r)
+		f.releases = append(f.releases, rel)
+		forgetest.Reply(w, http.StatusCreated, rel)
+	default:
+		forgetest.Reply(w, http.StatusNotFound, map[string]any{"message": "404 Not Found"})
+	}
+}
+
+// setup starts the GitLab stand-in and returns it with a plugin context
+// configured to use it.
+func setup(t *testing.T, branch, message string, props map[string]string) (*fakeGitlab, context.Context) {
+	t.Helper()
+
+	fake := &fakeGitlab{}
+	baseURL := forgetest.NewServer(t, fake)
+
+	forgetest.InitRepo(t, "git@127.0.0.1:group/zedpm.git", branch, message)
+	t.Setenv("GITLAB_TOKEN", "secret")
+
+	return fake, forgetest.Context(map[string]string{
+		zGitlab.PropertyGitlabBaseURL:          baseURL,
+		zGitlab.PropertyGitlabPipelineInterval: "10ms",
+	}, props)
+}
+
+func TestRelease(t *testing.T) {
+	fake, ctx := setup(t, "master", "initial", map[string]string{
+		goals.PropertyReleaseVersion:     "1.2.3",
+		goals.PropertyReleaseDescription: "Stuff.",
+	})
+	fake.pipelines = []string{"running", "running", "success"}
+
+	mint := &ReleaseMintTask{}
+	require.NoError(t, mint.Setup(ctx))
+	require.NoError(t, mint.CreateMergeRequest(ctx))
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	require.NoError(t, publish.Check(ctx))
+	require.NoError(t, publish.MergeMergeRequest(ctx))
+	require.NoError(t, publish.CreateRelease(ctx))
+
+	assert.Equal(t, []string{
+		"POST /merge_requests",
+		"GET /merge_requests",
+		"GET /merge_requests/1",
+		"GET /merge_requests",
+		"GET /merge_requests/1",
+		"GET /merge_requests",
+		"GET /merge_requests/1",
+		"GET /merge_requests",
+		"GET /merge_requests/1",
+		"PUT /merge_requests/1/merge",
+		"POST /releases",
+	}, fake.requests)
+
+	require.Len(t, fake.mrs, 1)
+	assert.Equal(t, "release-v1.2.3", fake.mrs[0]["source_branch"])
+	assert.Equal(t, "master", fake.mrs[0]["target_branch"])
+	assert.Equal(t, "Release v1.2.3", fake.mrs[0]["title"])
+	assert.Equal(t, true, fake.mrs[0]["remove_source_branch"])
+	assert.True(t, fake.merged)
+
+	require.Len(t, fake.releases, 1)
+	assert.Equal(t, map[string]any{
+		"tag_name":    "v1.2.3",
+		"name":        "Release v1.2.3",
+		"description": "Stuff.",
+		"ref":         "master",
+	}, fake.releases[0])
+}
+
+func TestReleasePipelineFailed(t *testing.T) {
+	fake, ctx := setup(t, "master", "initial", map[string]string{
+		goals.PropertyReleaseVersion: "1.2.3",
+	})
+	fake.pipelines = []string{"failed"}
+	fake.mrs = []map[string]any{{
+		"iid":           1,
+		"state":         "opened",
+		"source_branch": "release-v1.2.3",
+	}}
+
+	publish := &ReleasePublishTask{}
+	require.NoError(t, publish.Setup(ctx))
+	assert.EqualError(t, publish.Check(ctx),
+		`cannot merge release branch because pipeline 7 has status "failed": `)
+	assert.False(t, fake.merged)
+}
+
+func TestRequest(t *testing.T) {
+	fake, ctx := setup(t, "feature", "feat: add a thing\n\nThe thing is added.\n", nil)
+
+	request := &RequestCreateTask{}
+	require.NoError(t, request.Setup(ctx))
+	require.NoError(t, request.CreateMergeRequest(ctx))
+
+	require.Len(t, fake.mrs, 1)
+	assert.Equal(t, "feature", fake.mrs[0]["source_branch"])
+	assert.Equal(t, "master", fake.mrs[0]["target_branch"])
+	assert.Equal(t, "feat: add a thing", fake.mrs[0]["title"])
+	assert.Equal(t, "The thing is added.", fake.mrs[0]["description"])
+
+	// a second request for the same branch finds the first
+	require.NoError(t, request.CreateMergeRequest(ctx))
+	assert.Len(t, fake.mrs, 1)
+}
@@ -0,0 +1,110 @@
+package gitlabImpl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zostay/zedpm/format"
+	"github.com/zostay/zedpm/pkg/git"
+	zGitlab "github.com/zostay/zedpm/pkg/gitlab"
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/log"
+	"github.com/zostay/zedpm/plugin"
+)
+
+// RequestCreateTask implements the /request/create/gitlab task.
+type RequestCreateTask struct {
+	plugin.TaskBoilerplate
+	zGitlab.Gitlab
+}
+
+// Setup configures the GitLab and git clients.
+func (r *RequestCreateTask) Setup(ctx context.Context) error {
+	return r.SetupGitlabClient(ctx)
+}
+
+// CreateMergeRequest creates a merge request from the current branch into the
+// target branch. The title and description are taken from request.title and
+// request.description, defaulting to the subject and body of the last commit.
+// Nothing is done if the branch already has an open merge request.
+func (r *RequestCreateTask) CreateMergeRequest(ctx context.Context) error {
+	logger := plugin.Logger(ctx, "operation", "CreateMergeRequest")
+	logger.StartAction("CreateMergeRequest", "Creating GitLab merge request", "spin")
+
+	projectID, err := r.ProjectID(ctx)
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed getting owner/project information")
+	}
+
+	branch, commit, err := r.HeadBranch()
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "failed to get the branch to merge")
+	}
+
+	targetBranch := git.GetPropertyGitTargetBranch(ctx)
+	if branch == targetBranch {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return fmt.Errorf("cannot request a merge of branch %s into itself", branch)
+	}
+
+	logger = logger.With(
+		"project", projectID,
+		"branch", branch,
+		"targetBranch", targetBranch,
+	)
+	logger.TickAction("CreateMergeRequest")
+
+	existing, err := r.Client().FindMergeRequest(ctx, projectID, branch)
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "unable to find merge request for branch %s", branch)
+	}
+
+	if existing != nil {
+		logger.MarkAction("CreateMergeRequest", log.Pass)
+		logger.With("mergeRequestIID", existing.IID, "url", existing.WebURL).
+			Info("The branch already has an open merge request.")
+		return nil
+	}
+
+	subject, body, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+
+	title := goals.GetPropertyRequestTitle(ctx)
+	if title == "" {
+		title = subject
+	}
+
+	description := goals.GetPropertyRequestDescription(ctx)
+	if description == "" {
+		description = strings.TrimSpace(body)
+	}
+
+	mr, err := r.Client().CreateMergeRequest(ctx, projectID, &zGitlab.NewMergeRequest{
+		SourceBranch: branch,
+		TargetBranch: targetBranch,
+		Title:        title,
+		Description:  description,
+	})
+	if err != nil {
+		logger.MarkAction("CreateMergeRequest", log.Fail)
+		return format.WrapErr(err, "unable to create merge request")
+	}
+
+	logger.MarkAction("CreateMergeRequest", log.Pass)
+	logger.With("mergeRequestIID", mr.IID, "url", mr.WebURL).Info("Created the merge request.")
+
+	return nil
+}
+
+// Run configures CreateMergeRequest to run.
+func (r *RequestCreateTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(r.CreateMergeRequest),
+		},
+	}, nil
+}
@@ -0,0 +1,11 @@
+// Package main is the command that runs the zedpm-plugin-gitlab plugin.
+package main
+
+import (
+	"github.com/zostay/zedpm/plugin/metal"
+	"github.com/zostay/zedpm/zedpm-plugin-gitlab/gitlabImpl"
+)
+
+func main() {
+	metal.RunPlugin(&gitlabImpl.Plugin{})
+}
@@ -0,0 +1,93 @@
+package goImpl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/pkg/storage"
+	"github.com/zostay/zedpm/plugin"
+)
+
+const (
+	PropertyGoModule       = "go.module"
+	PropertyGoVersion      = "go.version"
+	PropertyGoToolchain    = "go.toolchain"
+	PropertyGoReplace      = "go.replace"
+	PropertyGoRetract      = "go.retract"
+	PropertyGoMainPackages = "go.mainPackages"
+	PropertyGoBinaries     = "go.binaries"
+	PropertyGoPackages     = "go.packages"
+	PropertyGoTestFiles    = "go.testFiles"
+)
+
+// InfoModuleTask implements the /info/module/go task, which describes the go
+// module and its packages.
+type InfoModuleTask struct {
+	plugin.TaskBoilerplate
+}
+
+// DescribeModule exports information about the main module read from go.mod
+// and go list.
+func (t *InfoModuleTask) DescribeModule(ctx context.Context) error {
+	mod, err := ReadModule(goals.ModulePath(ctx, "go.mod"))
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := ListPackages(ctx, goals.GetPropertyModuleDir(ctx))
+	if err != nil {
+		return err
+	}
+
+	var (
+		mainPkgs  = []string{}
+		binaries  = []string{}
+		testFiles = 0
+	)
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" {
+			mainPkgs = append(mainPkgs, pkg.ImportPath)
+			binaries = append(binaries, BinaryName(pkg.ImportPath))
+		}
+		testFiles += len(pkg.TestGoFiles) + len(pkg.XTestGoFiles)
+	}
+
+	plugin.AtomicProperties(ctx, func(kv storage.KV) {
+		kv.Set(PropertyGoModule, mod.Path)
+		kv.Set(PropertyGoVersion, mod.Go)
+		kv.Set(PropertyGoToolchain, mod.Toolchain)
+		kv.Set(PropertyGoReplace, strings.Join(mod.Replace, ","))
+		kv.Set(PropertyGoRetract, strings.Join(mod.Retract, ","))
+		kv.Set(PropertyGoMainPackages, strings.Join(mainPkgs, ","))
+		kv.Set(PropertyGoBinaries, strings.Join(binaries, ","))
+		kv.Set(PropertyGoPackages, len(pkgs))
+		kv.Set(PropertyGoTestFiles, testFiles)
+	})
+
+	for _, key := range []string{
+		PropertyGoModule,
+		PropertyGoVersion,
+		PropertyGoToolchain,
+		PropertyGoReplace,
+		PropertyGoRetract,
+		PropertyGoMainPackages,
+		PropertyGoBinaries,
+		PropertyGoPackages,
+		PropertyGoTestFiles,
+	} {
+		goals.ExportPropertyName(ctx, key)
+	}
+
+	return nil
+}
+
+// Run prepares the DescribeModule operation.
+func (t *InfoModuleTask) Run(context.Context) (plugin.Operations, error) {
+	return plugin.Operations{
+		{
+			Order:  50,
+			Action: plugin.OperationFunc(t.DescribeModule),
+		},
+	}, nil
+}
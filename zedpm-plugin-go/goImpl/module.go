@@ -0,0 +1,147 @@
+package goImpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/zostay/zedpm/format"
+)
+
+// Module summarizes the directives of a go.mod file.
+type Module struct {
+	// Path is the module path.
+	Path string
+
+	// Go is the version named by the go directive.
+	Go string
+
+	// Toolchain is the toolchain named by the toolchain directive.
+	Toolchain string
+
+	// Replace lists each replace directive as "old => new", where each side
+	// includes the version, if one is given, e.g., "example.com/a@v1.0.0 =>
+	// ../a".
+	Replace []string
+
+	// Retract lists each retracted version or, for a range of versions, the
+	// low and high version joined by "..", e.g., "v1.0.0..v1.0.5".
+	Retract []string
+}
+
+// ReadModule parses the named go.mod file.
+func ReadModule(filename string) (*Module, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to read %q", filename)
+	}
+
+	return ParseModule(filename, data)
+}
+
+// ParseModule parses the content of a go.mod file. The filename is only used
+// in error messages.
+func ParseModule(filename string, data []byte) (*Module, error) {
+	f, err := modfile.Parse(filename, data, nil)
+	if err != nil {
+		return nil, format.WrapErr(err, "unable to parse %q", filename)
+	}
+
+	if f.Module == nil {
+		return nil, errors.New("no module directive found in " + filename)
+	}
+
+	m := &Module{
+		Path:    f.Module.Mod.Path,
+		Replace: make([]string, len(f.Replace)),
+		Retract: make([]string, len(f.Retract)),
+	}
+
+	if f.Go != nil {
+		m.Go = f.Go.Version
+	}
+
+	if f.Toolchain != nil {
+		m.Toolchain = f.Toolchain.Name
+	}
+
+	for i, r := range f.Replace {
+		m.Replace[i] = r.Old.String() + " => " + r.New.String()
+	}
+
+	for i, r := range f.Retract {
+		m.Retract[i] = r.Low
+		if r.High != r.Low {
+			m.Retract[i] += ".." + r.High
+		}
+	}
+
+	return m, nil
+}
+
+// Package is the subset of the package information output by go list -json
+// that is used by zedpm.
+type Package struct {
+	ImportPath   string
+	Name         string
+	TestGoFiles  []string
+	XTestGoFiles []string
+}
+
+// ListPackages runs go list -json ./... in the given directory and returns the
+// packages found.
+func ListPackages(ctx context.Context, dir string) ([]*Package, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-json", "./...")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		return nil, format.WrapErr(err, "unable to run go list")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	pkgs := []*Package{}
+	for {
+		var pkg Package
+		err := dec.Decode(&pkg)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, format.WrapErr(err, "unable to parse go list output")
+		}
+
+		pkgs = append(pkgs, &pkg)
+	}
+
+	return pkgs, nil
+}
+
+// majorVersionSuffix matches the major version suffix of an import path.
+var majorVersionSuffix = regexp.MustCompile(`^v[2-9][0-9]*$`)
+
+// BinaryName returns the name of the binary go build or go install produces
+// for the main package with the given import path. This is the last element
+// of the path, unless that element is a major version suffix, in which case
+// it is the element before it.
+func BinaryName(importPath string) string {
+	dir, name := path.Split(importPath)
+	if majorVersionSuffix.MatchString(name) && dir != "" {
+		return path.Base(dir)
+	}
+	return name
+}
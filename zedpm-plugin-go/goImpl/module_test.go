@@ -0,0 +1,71 @@
+package goImpl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module example.com/frob/v2
+
+go 1.21
+
+toolchain go1.21.4
+
+require example.com/widget v1.2.3
+
+replace example.com/widget v1.2.3 => ../widget
+
+replace example.com/gadget => example.com/gizmo v0.1.0
+
+retract v2.0.1
+
+retract [v2.0.2, v2.0.5]
+`
+
+func TestParseModule(t *testing.T) {
+	t.Parallel()
+
+	mod, err := ParseModule("go.mod", []byte(testGoMod))
+	require.NoError(t, err)
+
+	assert.Equal(t, &Module{
+		Path:      "example.com/frob/v2",
+		Go:        "1.21",
+		Toolchain: "go1.21.4",
+		Replace: []string{
+			"example.com/widget@v1.2.3 => ../widget",
+			"example.com/gadget => example.com/gizmo@v0.1.0",
+		},
+		Retract: []string{
+			"v2.0.1",
+			"v2.0.2..v2.0.5",
+		},
+	}, mod)
+
+	_, err = ParseModule("go.mod", []byte("go 1.21\n"))
+	assert.Error(t, err)
+}
+
+func TestBinaryName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "zedpm", BinaryName("github.com/zostay/zedpm"))
+	assert.Equal(t, "frob", BinaryName("example.com/frob/v2"))
+	assert.Equal(t, "v2", BinaryName("v2"))
+}
+
+func TestListPackagesError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("frob example.com/frob\n"), 0o644))
+
+	_, err := ListPackages(context.Background(), dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to run go list: exit status 1: go: ")
+}
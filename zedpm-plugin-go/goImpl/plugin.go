@@ -13,11 +13,14 @@ var _ plugin.Interface = &Plugin{}
 // Plugin implements the plugin.Interface for performing tasks related to go.
 type Plugin struct{}
 
-// Implements provides task descriptions for /test/run/go tasks.
+// Implements provides task descriptions for /info/module/go,
+// /release/mint/go, and /test/run/go tasks.
 func (p *Plugin) Implements(ctx context.Context) ([]plugin.TaskDescription, error) {
+	info := goals.DescribeInfo()
 	release := goals.DescribeRelease()
 	test := goals.DescribeTest()
 	return []plugin.TaskDescription{
+		info.Task("module", "go", "Describe the go module and its packages."),
 		release.Task("mint", "go", "Run tests to ensure the project is ready for release."),
 		test.Task("run", "go", "Run the go test command."),
 	}, nil
@@ -34,6 +37,8 @@ func (p *Plugin) Prepare(
 	task string,
 ) (plugin.Task, error) {
 	switch task {
+	case "/info/module/go":
+		return &InfoModuleTask{}, nil
 	case "/release/mint/go":
 		return &ReleaseMintTask{}, nil
 	case "/test/run/go":
@@ -1,8 +1,20 @@
 package goalsImpl
 
-import "github.com/zostay/zedpm/plugin"
+import (
+	"context"
+
+	"github.com/zostay/zedpm/pkg/goals"
+	"github.com/zostay/zedpm/plugin"
+)
 
 // InfoDisplayTask provides an implementation of the /info/display task.
 type InfoDisplayTask struct {
 	plugin.TaskBoilerplate
 }
+
+// Check verifies that info.outputFormat names a known output format before any
+// information is gathered.
+func (t *InfoDisplayTask) Check(ctx context.Context) error {
+	_, err := goals.InfoOutputFormatter(ctx)
+	return err
+}
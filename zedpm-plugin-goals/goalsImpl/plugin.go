@@ -2,8 +2,11 @@ package goalsImpl
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/zostay/zedpm/format"
 	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/pkg/storage"
 	"github.com/zostay/zedpm/plugin"
@@ -72,13 +75,55 @@ func (p *Plugin) Cancel(context.Context, plugin.Task) error {
 }
 
 // Complete will output the accumulated properties if the /info/display task has
-// been executed.
+// been executed. If info.query is set, only the values it selects are output
+// and a single value selected by name is output as-is.
 func (p *Plugin) Complete(ctx context.Context, task plugin.Task) error {
 	var values storage.KV = plugin.KV(ctx)
 	outputAll := goals.GetPropertyInfoOutputAll(ctx)
-	if !outputAll {
+	query := goals.GetPropertyInfoQuery(ctx)
+	switch {
+	case len(query) > 0:
+		var err error
+		values, err = goals.QueryValues(values, query)
+		if err != nil {
+			return err
+		}
+
+		// a single value is output raw, for easy use in scripts
+		if key := goals.SingleQueryKey(values, query); key != "" {
+			value := values.GetString(key)
+			if !strings.HasSuffix(value, "\n") {
+				value += "\n"
+			}
+			_, err = fmt.Fprint(os.Stdout, value)
+			return err
+		}
+	case !outputAll:
 		values = storage.ExportsOnly(values)
+	default:
+		values = storage.PublicOnly(values)
+	}
+
+	formatter, err := goals.InfoOutputFormatter(ctx)
+	if err != nil {
+		return err
 	}
-	formatter := goals.InfoOutputFormatter(ctx)
+
+	// GitHub outputs go to $GITHUB_OUTPUT when running in GitHub Actions
+	if goals.GetPropertyInfoOutputFormat(ctx) == "github-output" {
+		if fn := os.Getenv("GITHUB_OUTPUT"); fn != "" {
+			w, err := os.OpenFile(fn, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				return format.WrapErr(err, "unable to open %s", fn)
+			}
+
+			err = formatter(w, values)
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		}
+	}
+
 	return formatter(os.Stdout, values)
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 
+	"github.com/zostay/zedpm/pkg/goals"
 	"github.com/zostay/zedpm/pkg/log"
 	"github.com/zostay/zedpm/plugin"
 )
@@ -13,6 +14,7 @@ func RunLinter(ctx context.Context) (*os.ProcessState, error) {
 	logger := plugin.Logger(ctx)
 
 	cmd := exec.CommandContext(ctx, "golangci-lint", "run", "./...")
+	cmd.Dir = goals.GetPropertyModuleDir(ctx)
 	cmd.Stdout = logger.Output(log.LevelInfo)
 	cmd.Stderr = logger.Output(log.LevelError)
 	err := cmd.Run()